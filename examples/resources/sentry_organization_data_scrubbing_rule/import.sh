@@ -0,0 +1,3 @@
+# import using the organization slug and the key of the rule in the data
+# scrubbing rules of the organization:
+terraform import sentry_organization_data_scrubbing_rule.default org-slug/rule-id
@@ -0,0 +1,7 @@
+# Hash the IP addresses of users in all projects of the organization
+resource "sentry_organization_data_scrubbing_rule" "ip_addresses" {
+  organization = "my-organization"
+  method       = "hash"
+  data_type    = "ip"
+  source       = "$user.ip_address"
+}
@@ -0,0 +1,3 @@
+# import using the organization and project slugs and the key of the rule in
+# the data scrubbing rules of the project:
+terraform import sentry_project_data_scrubbing_rule.default org-slug/project-slug/rule-id
@@ -0,0 +1,28 @@
+resource "sentry_project" "default" {
+  organization = "my-organization"
+
+  teams = ["my-first-team", "my-second-team"]
+  name  = "web-app"
+
+  platform = "javascript"
+}
+
+# Mask credit card numbers in all strings
+resource "sentry_project_data_scrubbing_rule" "credit_cards" {
+  organization = sentry_project.default.organization
+  project      = sentry_project.default.id
+  method       = "mask"
+  data_type    = "creditcard"
+  source       = "$string"
+}
+
+# Replace the matches of a custom pattern in a header
+resource "sentry_project_data_scrubbing_rule" "custom_token" {
+  organization = sentry_project.default.organization
+  project      = sentry_project.default.id
+  method       = "replace"
+  replacement  = "[token]"
+  data_type    = "pattern"
+  pattern      = "[a-f0-9]{32}"
+  source       = "$http.headers.x-custom-token"
+}
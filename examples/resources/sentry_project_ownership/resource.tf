@@ -0,0 +1,23 @@
+resource "sentry_project" "default" {
+  organization = "my-organization"
+
+  teams = ["my-first-team", "my-second-team"]
+  name  = "web-app"
+
+  platform = "javascript"
+}
+
+# Route the issues of the billing code to the billing team and a member
+resource "sentry_project_ownership" "default" {
+  organization = sentry_project.default.organization
+  project      = sentry_project.default.id
+
+  raw = <<-EOT
+    path:src/billing/* #my-first-team jane@example.com
+    url:*/checkout/* #my-first-team
+    tags.sku_class:enterprise #my-second-team
+  EOT
+
+  fallthrough     = false
+  auto_assignment = "Auto Assign to Issue Owner"
+}
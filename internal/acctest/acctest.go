@@ -3,10 +3,12 @@ package acctest
 import (
 	"context"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/jianyuan/go-sentry/v2/sentry"
 	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentrytest"
 )
 
 const (
@@ -31,10 +33,28 @@ var (
 
 	// SharedClient is a shared Sentry client for acceptance tests.
 	SharedClient *sentry.Client
+
+	// FakeServer is the fake Sentry API used for acceptance tests when SENTRY_TEST_FAKE is set.
+	FakeServer *sentrytest.Server
 )
 
 func init() {
 	var err error
+	if v, _ := strconv.ParseBool(os.Getenv("SENTRY_TEST_FAKE")); v {
+		startFakeServer()
+	}
+	if err := setupCassettes(); err != nil {
+		panic(err)
+	}
+
+	SharedClient, err = newSharedClient()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// newSharedClient returns a Sentry client configured from the environment.
+func newSharedClient() (*sentry.Client, error) {
 	var token string
 	if v := os.Getenv("SENTRY_AUTH_TOKEN"); v != "" {
 		token = v
@@ -53,10 +73,7 @@ func init() {
 		Token:   token,
 		BaseURL: baseUrl,
 	}
-	SharedClient, err = config.Client(context.Background())
-	if err != nil {
-		panic(err)
-	}
+	return config.Client(context.Background())
 }
 
 func PreCheck(t *testing.T) {
@@ -66,4 +83,32 @@ func PreCheck(t *testing.T) {
 	if v := os.Getenv("SENTRY_TEST_ORGANIZATION"); v == "" {
 		t.Fatal("SENTRY_TEST_ORGANIZATION must be set for acceptance tests")
 	}
+	if cassettesMode != "" {
+		startRecorder(t)
+	}
+}
+
+// startFakeServer starts an in-process fake of the Sentry API and points the
+// acceptance tests and the provider at it.
+func startFakeServer() {
+	FakeServer = sentrytest.NewUnstartedServer()
+	FakeServer.Token = "fake-token"
+	FakeServer.Start()
+
+	if TestOrganization == "" {
+		TestOrganization = sentrytest.DefaultOrganization
+	} else {
+		FakeServer.AddOrganization(TestOrganization)
+	}
+
+	TestPagerDutyOrganization = "terraform-provider-sentry-pagerduty"
+	FakeServer.AddIntegration(TestOrganization, "pagerduty", TestPagerDutyOrganization)
+
+	TestOpsgenieOrganization = "terraform-provider-sentry-opsgenie"
+	TestOpsgenieIntegrationKey = "00000000-0000-0000-0000-000000000000"
+	FakeServer.AddIntegration(TestOrganization, "opsgenie", TestOpsgenieOrganization)
+
+	os.Setenv("SENTRY_BASE_URL", FakeServer.BaseURL())
+	os.Setenv("SENTRY_AUTH_TOKEN", FakeServer.Token)
+	os.Setenv("SENTRY_TEST_ORGANIZATION", TestOrganization)
 }
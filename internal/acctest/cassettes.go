@@ -0,0 +1,196 @@
+package acctest
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentrytest"
+)
+
+const (
+	// CassettesRecord records the requests of each acceptance test to its
+	// cassette.
+	CassettesRecord = "record"
+	// CassettesReplay replays the requests of each acceptance test from its
+	// cassette, without network access.
+	CassettesReplay = "replay"
+)
+
+// CassettesDir is the directory, relative to the package under test, holding
+// the cassettes of its acceptance tests.
+const CassettesDir = "testdata/cassettes"
+
+// Placeholders stored in cassettes for the organizations of the account the
+// cassettes were recorded with. They are also the organizations replayed
+// when none is configured, so that replaying needs no secrets.
+const (
+	cassetteOrganization          = "terraform-provider-sentry-test"
+	cassettePagerDutyOrganization = "terraform-provider-sentry-test-pagerduty"
+	cassetteOpsgenieOrganization  = "terraform-provider-sentry-test-opsgenie"
+	cassetteOpsgenieKey           = "opsgenie-integration-key"
+)
+
+var (
+	// cassettesMode is CassettesRecord, CassettesReplay or empty.
+	cassettesMode = os.Getenv("SENTRY_TEST_CASSETTES")
+
+	recorderMu sync.Mutex
+	// recorder is the recorder of the running acceptance test.
+	recorder *sentrytest.Recorder
+	// randomNames are the random names generated for the running acceptance
+	// test, or for the next one if none is running.
+	randomNames []randomName
+)
+
+type randomName struct {
+	prefix string
+	value  string
+}
+
+// setupCassettes routes the requests of the provider and SharedClient through
+// the recorder of the running acceptance test, if SENTRY_TEST_CASSETTES is
+// set.
+func setupCassettes() error {
+	switch cassettesMode {
+	case "":
+		return nil
+	case CassettesRecord:
+	case CassettesReplay:
+		// Requests are not sent, so any token and organizations will do
+		if os.Getenv("SENTRY_AUTH_TOKEN") == "" && os.Getenv("SENTRY_TOKEN") == "" {
+			os.Setenv("SENTRY_AUTH_TOKEN", "replay")
+		}
+		if TestOrganization == "" {
+			TestOrganization = cassetteOrganization
+			os.Setenv("SENTRY_TEST_ORGANIZATION", TestOrganization)
+		}
+		if TestPagerDutyOrganization == "" {
+			TestPagerDutyOrganization = cassettePagerDutyOrganization
+		}
+		if TestOpsgenieOrganization == "" {
+			TestOpsgenieOrganization = cassetteOpsgenieOrganization
+		}
+		if TestOpsgenieIntegrationKey == "" {
+			TestOpsgenieIntegrationKey = cassetteOpsgenieKey
+		}
+	default:
+		return fmt.Errorf("SENTRY_TEST_CASSETTES must be %q or %q, got %q", CassettesRecord, CassettesReplay, cassettesMode)
+	}
+
+	sentryclient.DefaultWrapTransport = wrapTransport
+	return nil
+}
+
+func wrapTransport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		recorderMu.Lock()
+		r := recorder
+		recorderMu.Unlock()
+
+		if r != nil {
+			return r.Wrap(next).RoundTrip(req)
+		}
+		if cassettesMode == CassettesReplay {
+			return nil, fmt.Errorf("no cassette is loaded to replay %s %s", req.Method, req.URL.RequestURI())
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+// startRecorder records or replays the requests of t until it completes.
+func startRecorder(t *testing.T) {
+	t.Helper()
+
+	path := filepath.Join(CassettesDir, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+	mode := sentrytest.ModeRecord
+	if cassettesMode == CassettesReplay {
+		mode = sentrytest.ModeReplay
+	}
+
+	r, err := sentrytest.NewRecorder(path, mode)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("no cassette at %s, record it with SENTRY_TEST_CASSETTES=%s (make testacc-record)", path, CassettesRecord)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Redact(os.Getenv("SENTRY_AUTH_TOKEN"))
+	r.Redact(os.Getenv("SENTRY_TOKEN"))
+	r.Replace(TestOrganization, cassetteOrganization)
+	r.Replace(TestPagerDutyOrganization, cassettePagerDutyOrganization)
+	r.Replace(TestOpsgenieOrganization, cassetteOpsgenieOrganization)
+	r.Replace(TestOpsgenieIntegrationKey, cassetteOpsgenieKey)
+
+	// Start from a clean slate, so that the requests do not depend on the
+	// tests run before
+	sentryclient.InvalidateSharedCaches()
+	client, err := newSharedClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	SharedClient = client
+
+	recorderMu.Lock()
+	recorder = r
+	names := randomNames
+	randomNames = nil
+	for _, name := range names {
+		addRandomName(name)
+	}
+	recorderMu.Unlock()
+
+	t.Cleanup(func() {
+		recorderMu.Lock()
+		recorder = nil
+		randomNames = nil
+		recorderMu.Unlock()
+
+		if t.Failed() {
+			return
+		}
+		if err := r.Save(); err != nil {
+			t.Errorf("unable to save cassette: %s", err)
+		}
+	})
+}
+
+// recordRandomName registers a random name generated by RandomWithPrefix, so
+// that it is stored as a placeholder in cassettes.
+func recordRandomName(prefix string, value string) {
+	if cassettesMode == "" {
+		return
+	}
+
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+
+	name := randomName{prefix: prefix, value: value}
+	if recorder != nil {
+		addRandomName(name)
+	} else {
+		randomNames = append(randomNames, name)
+	}
+}
+
+// addRandomName replaces a random name with a placeholder numbered in order of
+// generation, so that the placeholders of a test are the same on each run.
+// recorderMu must be held.
+func addRandomName(name randomName) {
+	randomNames = append(randomNames, name)
+	recorder.Replace(name.value, fmt.Sprintf("%s-random-%d", name.prefix, len(randomNames)))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -5,5 +5,7 @@ import (
 )
 
 func RandomWithPrefix(name string) string {
-	return sdkacctest.RandomWithPrefix(name)
+	v := sdkacctest.RandomWithPrefix(name)
+	recordRandomName(name, v)
+	return v
 }
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentrytypes"
+)
+
+// dataScrubbingRuleDataTypes are the data types of the advanced data scrubbing
+// rules in the Sentry UI.
+var dataScrubbingRuleDataTypes = []string{
+	"anything",
+	"creditcard",
+	"email",
+	"iban",
+	"imei",
+	"ip",
+	"mac",
+	"password",
+	"pattern",
+	"pemkey",
+	"urlauth",
+	"userpath",
+	"usssn",
+	"uuid",
+}
+
+var dataScrubbingRuleMethods = []string{
+	"hash",
+	"mask",
+	"remove",
+	"replace",
+}
+
+// dataScrubbingRule are the attributes of a rule shared by the data scrubbing
+// rule resources.
+type dataScrubbingRule struct {
+	RuleId      types.String
+	Method      types.String
+	Replacement types.String
+	DataType    types.String
+	Pattern     types.String
+	Source      types.String
+}
+
+// dataScrubbingRuleAttributes returns the schema of the attributes of
+// dataScrubbingRule.
+func dataScrubbingRuleAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"rule_id": schema.StringAttribute{
+			MarkdownDescription: "The key of the rule in the data scrubbing rules.",
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"method": schema.StringAttribute{
+			MarkdownDescription: "How the matching data is scrubbed. One of `mask`, `remove`, `hash` or `replace`.",
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(dataScrubbingRuleMethods...),
+			},
+		},
+		"replacement": schema.StringAttribute{
+			MarkdownDescription: "The text that replaces the matching data when the `method` is `replace`.",
+			Optional:            true,
+		},
+		"data_type": schema.StringAttribute{
+			MarkdownDescription: "The type of data that is scrubbed. One of `anything`, `creditcard`, `email`, `iban`, `imei`, `ip`, `mac`, `password`, `pattern`, `pemkey`, `urlauth`, `userpath`, `usssn` or `uuid`.",
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(dataScrubbingRuleDataTypes...),
+			},
+		},
+		"pattern": schema.StringAttribute{
+			MarkdownDescription: "The regular expression of the data that is scrubbed when the `data_type` is `pattern`.",
+			Optional:            true,
+		},
+		"source": schema.StringAttribute{
+			MarkdownDescription: "The selector of the fields the rule applies to, such as `$string`, `$http.headers.x-custom-token` or `extra.password`. See the [Sentry documentation](https://docs.sentry.io/security-legal-pii/scrubbing/advanced-datascrubbing/#source-selector) for the syntax.",
+			Required:            true,
+		},
+	}
+}
+
+// validateDataScrubbingRuleConfig checks that `pattern` and `replacement` are
+// only set for the data type and method that use them.
+func validateDataScrubbingRuleConfig(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var dataType, pattern, method, replacement types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("data_type"), &dataType)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("pattern"), &pattern)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("method"), &method)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("replacement"), &replacement)...)
+	if diags.HasError() {
+		return
+	}
+
+	if !dataType.IsUnknown() && !pattern.IsUnknown() {
+		if dataType.ValueString() == "pattern" && pattern.IsNull() {
+			diags.AddAttributeError(path.Root("pattern"), "Missing pattern", "The `pattern` must be set when the `data_type` is `pattern`.")
+		}
+		if !dataType.IsNull() && dataType.ValueString() != "pattern" && !pattern.IsNull() {
+			diags.AddAttributeError(path.Root("pattern"), "Unexpected pattern", "The `pattern` can only be set when the `data_type` is `pattern`.")
+		}
+	}
+	if !method.IsUnknown() && !method.IsNull() && method.ValueString() != "replace" && !replacement.IsNull() {
+		diags.AddAttributeError(path.Root("replacement"), "Unexpected replacement", "The `replacement` can only be set when the `method` is `replace`.")
+	}
+}
+
+func (m dataScrubbingRule) relayRule() sentryclient.RelayPiiRule {
+	return sentryclient.RelayPiiRule{
+		Type:    m.DataType.ValueString(),
+		Pattern: m.Pattern.ValueString(),
+		Redaction: sentryclient.RelayPiiRedaction{
+			Method: m.Method.ValueString(),
+			Text:   m.Replacement.ValueString(),
+		},
+	}
+}
+
+// Fill sets the attributes from the rule with the given key.
+func (m *dataScrubbingRule) Fill(key string, rule sentryclient.RelayPiiRule, source string) {
+	m.RuleId = types.StringValue(key)
+	m.Method = types.StringValue(rule.Redaction.Method)
+	m.Replacement = stringValueOrNull(rule.Redaction.Text)
+	m.DataType = types.StringValue(rule.Type)
+	m.Pattern = stringValueOrNull(rule.Pattern)
+	m.Source = types.StringValue(source)
+}
+
+func stringValueOrNull(v string) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(v)
+}
+
+// find returns the key of the rule of m in config. Saving the rules in the
+// Sentry UI renumbers them, so a rule that is no longer found by its key is
+// looked up by its content and source instead.
+func (m dataScrubbingRule) find(ctx context.Context, config *sentryclient.RelayPiiConfig) (string, bool) {
+	if _, ok := config.Rules[m.RuleId.ValueString()]; ok {
+		return m.RuleId.ValueString(), true
+	}
+
+	want, err := json.Marshal(m.relayRule())
+	if err != nil || m.Source.IsNull() {
+		return "", false
+	}
+
+	keys := make([]string, 0, len(config.Rules))
+	for key := range config.Rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if source, ok := config.Source(key); !ok || source != m.Source.ValueString() {
+			continue
+		}
+		// Sentry may add fields to the rules it stores
+		equal, diags := sentrytypes.NewLossyJsonValue(string(config.Rules[key])).StringSemanticEquals(ctx, sentrytypes.NewLossyJsonValue(string(want)))
+		if !diags.HasError() && equal {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// newDataScrubbingRuleKey returns a key for a new rule that does not clash
+// with the ones the Sentry UI numbers its rules with.
+func newDataScrubbingRuleKey() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "terraform-" + hex.EncodeToString(b), nil
+}
+
+// relayPiiConfigStore reads and writes the data scrubbing rules of a project
+// or an organization.
+type relayPiiConfigStore struct {
+	lockKey string
+	get     func(ctx context.Context) (*sentryclient.RelayPiiConfig, error)
+	update  func(ctx context.Context, config *sentryclient.RelayPiiConfig) error
+}
+
+// modify changes the rules with fn. The rules are locked while they are read,
+// modified and written, so that the rule resources of the same project or
+// organization do not overwrite each other's changes.
+func (s relayPiiConfigStore) modify(ctx context.Context, fn func(config *sentryclient.RelayPiiConfig) error) error {
+	unlock, err := providerdata.Lock(ctx, s.lockKey)
+	if err != nil {
+		return fmt.Errorf("waiting for the other changes to the data scrubbing rules: %w", err)
+	}
+	defer unlock()
+
+	config, err := s.get(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(config); err != nil {
+		return err
+	}
+	return s.update(ctx, config)
+}
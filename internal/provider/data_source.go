@@ -5,11 +5,19 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
 )
 
 type baseDataSource struct {
-	client *sentry.Client
+	providerData        *providerdata.ProviderData
+	client              *sentry.Client
+	defaultOrganization string
+	cache               *sentryclient.Cache
 }
 
 func (d *baseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -18,16 +26,41 @@ func (d *baseDataSource) Configure(ctx context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*sentry.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sentry.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
+	d.client = data.ClientFor(ctx)
+	d.defaultOrganization = data.DefaultOrganization
+	d.cache = data.Cache
+}
+
+// resolveOrganization sets organization to the provider-level default if it
+// is not set on the data source.
+func (d *baseDataSource) resolveOrganization(organization *types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !organization.IsNull() {
+		return diags
+	}
+
+	if d.defaultOrganization == "" {
+		diags.AddAttributeError(
+			path.Root("organization"),
+			"Missing organization",
+			"The organization must be set either on the data source or on the provider (or via the SENTRY_ORGANIZATION environment variable).",
+		)
+		return diags
+	}
+
+	*organization = types.StringValue(d.defaultOrganization)
+	return diags
 }
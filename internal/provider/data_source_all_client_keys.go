@@ -23,11 +23,50 @@ type AllClientKeysDataSource struct {
 	baseDataSource
 }
 
+type AllClientKeysDataSourceKeyModel struct {
+	Id              types.String `tfsdk:"id"`
+	Organization    types.String `tfsdk:"organization"`
+	Project         types.String `tfsdk:"project"`
+	ProjectId       types.String `tfsdk:"project_id"`
+	Name            types.String `tfsdk:"name"`
+	RateLimitWindow types.Int64  `tfsdk:"rate_limit_window"`
+	RateLimitCount  types.Int64  `tfsdk:"rate_limit_count"`
+	Public          types.String `tfsdk:"public"`
+	Secret          types.String `tfsdk:"secret"`
+	DsnPublic       types.String `tfsdk:"dsn_public"`
+	DsnSecret       types.String `tfsdk:"dsn_secret"`
+	DsnCsp          types.String `tfsdk:"dsn_csp"`
+}
+
+func (m *AllClientKeysDataSourceKeyModel) Fill(organization string, project string, key sentry.ProjectKey) error {
+	m.Id = types.StringValue(key.ID)
+	m.Organization = types.StringValue(organization)
+	m.Project = types.StringValue(project)
+
+	if key.RateLimit == nil {
+		m.RateLimitWindow = types.Int64Null()
+		m.RateLimitCount = types.Int64Null()
+	} else {
+		m.RateLimitWindow = types.Int64Value(int64(key.RateLimit.Window))
+		m.RateLimitCount = types.Int64Value(int64(key.RateLimit.Count))
+	}
+
+	m.ProjectId = types.StringValue(key.ProjectID.String())
+	m.Name = types.StringValue(key.Name)
+	m.Public = types.StringValue(key.Public)
+	m.Secret = types.StringValue(key.Secret)
+	m.DsnPublic = types.StringValue(key.DSN.Public)
+	m.DsnSecret = types.StringValue(key.DSN.Secret)
+	m.DsnCsp = types.StringValue(key.DSN.CSP)
+
+	return nil
+}
+
 type AllClientKeysDataSourceModel struct {
-	Organization types.String             `tfsdk:"organization"`
-	Project      types.String             `tfsdk:"project"`
-	FilterStatus types.String             `tfsdk:"filter_status"`
-	Keys         []ClientKeyResourceModel `tfsdk:"keys"`
+	Organization types.String                      `tfsdk:"organization"`
+	Project      types.String                      `tfsdk:"project"`
+	FilterStatus types.String                      `tfsdk:"filter_status"`
+	Keys         []AllClientKeysDataSourceKeyModel `tfsdk:"keys"`
 }
 
 func (m *AllClientKeysDataSourceModel) Fill(organization string, project string, filterStatus *string, keys []*sentry.ProjectKey) error {
@@ -35,9 +74,9 @@ func (m *AllClientKeysDataSourceModel) Fill(organization string, project string,
 	m.Project = types.StringValue(project)
 	m.FilterStatus = types.StringPointerValue(filterStatus)
 
-	m.Keys = []ClientKeyResourceModel{}
+	m.Keys = []AllClientKeysDataSourceKeyModel{}
 	for _, key := range keys {
-		var model ClientKeyResourceModel
+		var model AllClientKeysDataSourceKeyModel
 		if err := model.Fill(organization, project, *key); err != nil {
 			return err
 		}
@@ -58,8 +97,9 @@ func (d *AllClientKeysDataSource) Schema(ctx context.Context, req datasource.Sch
 
 		Attributes: map[string]schema.Attribute{
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"project": schema.StringAttribute{
 				MarkdownDescription: "The slug of the project the resource belongs to.",
@@ -139,6 +179,7 @@ func (d *AllClientKeysDataSource) Read(ctx context.Context, req datasource.ReadR
 	var data AllClientKeysDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(d.resolveOrganization(&data.Organization)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -86,8 +86,9 @@ func (d *AllProjectsDataSource) Schema(ctx context.Context, req datasource.Schem
 
 		Attributes: map[string]schema.Attribute{
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"project_slugs": schema.SetAttribute{
 				MarkdownDescription: "The slugs of the projects.",
@@ -139,28 +140,20 @@ func (d *AllProjectsDataSource) Read(ctx context.Context, req datasource.ReadReq
 	var data AllProjectsDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(d.resolveOrganization(&data.Organization)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var allProjects []sentry.Project
-	params := &sentry.ListOrganizationProjectsParams{}
-
-	for {
-		projects, apiResp, err := d.client.OrganizationProjects.List(ctx, data.Organization.ValueString(), params)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err))
-			return
-		}
-
-		for _, project := range projects {
-			allProjects = append(allProjects, *project)
-		}
+	projects, err := d.cache.Projects(ctx, d.providerData.Client, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err))
+		return
+	}
 
-		if apiResp.Cursor == "" {
-			break
-		}
-		params.Cursor = apiResp.Cursor
+	var allProjects []sentry.Project
+	for _, project := range projects {
+		allProjects = append(allProjects, *project)
 	}
 
 	if err := data.Fill(data.Organization.ValueString(), allProjects); err != nil {
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
@@ -12,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
 )
 
 var _ datasource.DataSource = &ClientKeyDataSource{}
@@ -77,8 +77,9 @@ func (d *ClientKeyDataSource) Schema(ctx context.Context, req datasource.SchemaR
 
 		Attributes: map[string]schema.Attribute{
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"project": schema.StringAttribute{
 				MarkdownDescription: "The slug of the project the resource belongs to.",
@@ -146,6 +147,7 @@ func (d *ClientKeyDataSource) Read(ctx context.Context, req datasource.ReadReque
 	var data ClientKeyDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(d.resolveOrganization(&data.Organization)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -199,13 +201,13 @@ func (d *ClientKeyDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	} else {
 		// Get the key by ID
-		key, apiResp, err := d.client.ProjectKeys.Get(
+		key, _, err := d.client.ProjectKeys.Get(
 			ctx,
 			data.Organization.ValueString(),
 			data.Project.ValueString(),
 			data.Id.ValueString(),
 		)
-		if apiResp.StatusCode == http.StatusNotFound {
+		if sentryclient.IsNotFound(err) {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Not found: %s", err.Error()))
 			return
 		}
@@ -4,12 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
 	"github.com/jianyuan/terraform-provider-sentry/internal/sentrytypes"
 )
 
@@ -101,8 +101,9 @@ func (d *IssueAlertDataSource) Schema(ctx context.Context, req datasource.Schema
 				Required:    true,
 			},
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"project": schema.StringAttribute{
 				MarkdownDescription: "The slug of the project the resource belongs to.",
@@ -152,23 +153,23 @@ func (d *IssueAlertDataSource) Schema(ctx context.Context, req datasource.Schema
 }
 
 func (d *IssueAlertDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data IssueAlertResourceModel
+	var data IssueAlertDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(d.resolveOrganization(&data.Organization)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	action, apiResp, err := d.client.IssueAlerts.Get(
+	action, _, err := d.client.IssueAlerts.Get(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 		data.Id.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
+	if sentryclient.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Issue alert not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
 		return
 	}
 	if err != nil {
@@ -58,8 +58,9 @@ func (d *OrganizationIntegrationDataSource) Schema(ctx context.Context, req data
 				DeprecationMessage:  "This field is deprecated and will be removed in a future version. Use `id` instead.",
 			},
 			"organization": schema.StringAttribute{
-				Description: "The slug of the organization.",
-				Required:    true,
+				Description: "The slug of the organization. Defaults to the provider-level `organization`.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"provider_key": schema.StringAttribute{
 				Description: "Specific integration provider to filter by such as `slack`. See [the list of supported providers](https://docs.sentry.io/product/integrations/).",
@@ -77,32 +78,22 @@ func (d *OrganizationIntegrationDataSource) Read(ctx context.Context, req dataso
 	var data OrganizationIntegrationDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(d.resolveOrganization(&data.Organization)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var matchedIntegrations []*sentry.OrganizationIntegration
-	params := &sentry.ListOrganizationIntegrationsParams{
-		ListCursorParams: sentry.ListCursorParams{},
-		ProviderKey:      data.ProviderKey.ValueString(),
+	integrations, err := d.cache.Integrations(ctx, d.providerData.Client, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization integrations, got error: %s", err))
+		return
 	}
-	for {
-		integrations, apiResp, err := d.client.OrganizationIntegrations.List(ctx, data.Organization.ValueString(), params)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read organization integrations, got error: %s", err))
-			return
-		}
-
-		for _, integration := range integrations {
-			if integration.Name == data.Name.ValueString() {
-				matchedIntegrations = append(matchedIntegrations, integration)
-			}
-		}
 
-		if apiResp.Cursor == "" {
-			break
+	var matchedIntegrations []*sentry.OrganizationIntegration
+	for _, integration := range integrations {
+		if integration.Provider.Key == data.ProviderKey.ValueString() && integration.Name == data.Name.ValueString() {
+			matchedIntegrations = append(matchedIntegrations, integration)
 		}
-		params.ListCursorParams.Cursor = apiResp.Cursor
 	}
 
 	if len(matchedIntegrations) == 0 {
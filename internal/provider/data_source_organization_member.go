@@ -51,8 +51,9 @@ func (d *OrganizationMemberDataSource) Schema(ctx context.Context, req datasourc
 				Computed:            true,
 			},
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"email": schema.StringAttribute{
 				MarkdownDescription: "The email of the organization member.",
@@ -70,32 +71,23 @@ func (d *OrganizationMemberDataSource) Read(ctx context.Context, req datasource.
 	var data OrganizationMemberDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(d.resolveOrganization(&data.Organization)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var foundMember *sentry.OrganizationMember
-	params := &sentry.ListCursorParams{}
-
-out:
-	for {
-		members, apiResp, err := d.client.OrganizationMembers.List(ctx, data.Organization.ValueString(), params)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list organization members, got error: %s", err))
-			return
-		}
-
-		for _, member := range members {
-			if member.Email == data.Email.ValueString() {
-				foundMember = member
-				break out
-			}
-		}
+	members, err := d.cache.Members(ctx, d.providerData.Client, data.Organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list organization members, got error: %s", err))
+		return
+	}
 
-		if apiResp.Cursor == "" {
+	var foundMember *sentry.OrganizationMember
+	for _, member := range members {
+		if member.Email == data.Email.ValueString() {
+			foundMember = member
 			break
 		}
-		params.Cursor = apiResp.Cursor
 	}
 
 	if foundMember == nil {
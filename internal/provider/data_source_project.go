@@ -3,13 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
 )
 
 var _ datasource.DataSource = &ProjectDataSource{}
@@ -67,8 +67,9 @@ func (d *ProjectDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 
 		Attributes: map[string]schema.Attribute{
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"slug": schema.StringAttribute{
 				MarkdownDescription: "The slug of this project.",
@@ -115,12 +116,13 @@ func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	var data ProjectDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	resp.Diagnostics.Append(d.resolveOrganization(&data.Organization)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	project, apiResp, err := d.client.Projects.Get(ctx, data.Organization.ValueString(), data.Slug.ValueString())
-	if apiResp.StatusCode == http.StatusNotFound {
+	project, _, err := d.client.Projects.Get(ctx, data.Organization.ValueString(), data.Slug.ValueString())
+	if sentryclient.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Not found: %s", err.Error()))
 		return
 	}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+)
+
+// addClientError adds the diagnostics for an error returned by a request made
+// from the planned values. Each validation error of a field of the request is
+// added to the attribute the field was set from, see
+// sentryclient.AttributePath for fieldPaths.
+func addClientError(ctx context.Context, diags *diag.Diagnostics, plan tfsdk.Plan, fieldPaths map[string]string, message string, err error) {
+	details, ok := sentryclient.ParseErrorResponse(err)
+	if !ok {
+		diags.AddError("Client Error", fmt.Sprintf("%s: %s", message, err.Error()))
+		return
+	}
+
+	var requestID string
+	if details.RequestID != "" {
+		requestID = fmt.Sprintf("\n\nSentry request ID: %s", details.RequestID)
+	}
+
+	if len(details.FieldErrors) == 0 {
+		diags.AddError("Client Error", fmt.Sprintf("%s: %s%s", message, err.Error(), requestID))
+		return
+	}
+
+	for _, m := range details.Messages {
+		diags.AddError("Client Error", fmt.Sprintf("%s: %s%s", message, m, requestID))
+	}
+	for _, fieldErr := range details.FieldErrors {
+		attributePath, exact := planAttributePath(ctx, plan, sentryclient.AttributePath(fieldErr.Path, fieldPaths))
+
+		detail := fieldErr.Message
+		if !exact {
+			detail = fmt.Sprintf("%s: %s", strings.Join(fieldErr.Path, "."), detail)
+		}
+		detail = fmt.Sprintf("%s: %s%s", message, detail, requestID)
+
+		if len(attributePath.Steps()) == 0 {
+			diags.AddError("Client Error", detail)
+		} else {
+			diags.AddAttributeError(attributePath, "Client Error", detail)
+		}
+	}
+}
+
+// planAttributePath returns the longest prefix of attributePath that is in
+// the plan, and whether it is the whole path.
+func planAttributePath(ctx context.Context, plan tfsdk.Plan, attributePath []string) (path.Path, bool) {
+	var p, valid path.Path
+	for i, segment := range attributePath {
+		switch index, err := strconv.Atoi(segment); {
+		case i == 0:
+			p = path.Root(segment)
+		case err == nil:
+			p = p.AtListIndex(index)
+		default:
+			p = p.AtName(segment)
+		}
+
+		var v attr.Value
+		if diags := plan.GetAttribute(ctx, p, &v); diags.HasError() {
+			return valid, false
+		}
+		valid = p
+	}
+	return valid, true
+}
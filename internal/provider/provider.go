@@ -4,16 +4,30 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/providervalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+	"golang.org/x/oauth2"
 )
 
 var _ provider.Provider = &SentryProvider{}
+var _ provider.ProviderWithConfigValidators = &SentryProvider{}
 
 // SentryProvider defines the provider implementation.
 type SentryProvider struct {
@@ -21,12 +35,41 @@ type SentryProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// data is the provider data of the last configuration, read by the
+	// operation server to check the token scopes of planned changes.
+	data atomic.Pointer[providerdata.ProviderData]
 }
 
 // SentryProviderModel describes the provider data model.
 type SentryProviderModel struct {
-	Token   types.String `tfsdk:"token"`
-	BaseUrl types.String `tfsdk:"base_url"`
+	Token           types.String `tfsdk:"token"`
+	TokenFile       types.String `tfsdk:"token_file"`
+	TokenCommand    types.List   `tfsdk:"token_command"`
+	TokenCommandTtl types.String `tfsdk:"token_command_ttl"`
+	TokenScopeCheck types.String `tfsdk:"token_scope_check"`
+	BaseUrl         types.String `tfsdk:"base_url"`
+	Organization    types.String `tfsdk:"organization"`
+	ReadOnly        types.Bool   `tfsdk:"read_only"`
+	AuditLogPath    types.String `tfsdk:"audit_log_path"`
+
+	DeletionProtection     types.Bool `tfsdk:"deletion_protection"`
+	AdoptOnAmbiguousCreate types.Bool `tfsdk:"adopt_on_ambiguous_create"`
+
+	MaxRetries       types.Int64  `tfsdk:"max_retries"`
+	MinBackoff       types.String `tfsdk:"min_backoff"`
+	MaxBackoff       types.String `tfsdk:"max_backoff"`
+	RequestTimeout   types.String `tfsdk:"request_timeout"`
+	RetryStatusCodes types.Set    `tfsdk:"retry_status_codes"`
+	MaxRateLimitWait types.String `tfsdk:"max_rate_limit_wait"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	CACert             types.String `tfsdk:"ca_cert"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyUrl           types.String `tfsdk:"proxy_url"`
 }
 
 func (p *SentryProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -38,18 +81,146 @@ func (p *SentryProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The authentication token used to connect to Sentry. The value can be sourced from the `SENTRY_AUTH_TOKEN` environment variable.",
+				MarkdownDescription: "The authentication token used to connect to Sentry. The value can be sourced from the `SENTRY_AUTH_TOKEN` environment variable. Conflicts with `token_file` and `token_command`.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token_file": schema.StringAttribute{
+				MarkdownDescription: "The path to a file containing the authentication token. The file is read each time the provider is configured. Conflicts with `token` and `token_command`.",
+				Optional:            true,
+			},
+			"token_command": schema.ListAttribute{
+				MarkdownDescription: "A command that prints the authentication token to its standard output, as a list of the executable followed by its arguments, e.g. `[\"vault\", \"read\", \"-field=token\", \"secret/sentry\"]`. The command is killed if it runs for more than 30 seconds. Conflicts with `token` and `token_file`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"token_command_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the token printed by `token_command` is cached before the command is run again, as a duration string such as `5m`. The default value is `5m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"token_scope_check": schema.StringAttribute{
+				MarkdownDescription: "Whether to check that the token has the scopes each resource needs before changing it: `off`, `warn` or `error`. When enabled, the provider reads the type and scopes of the token when it is configured, plans that create or update a resource show a warning or an error naming the missing scopes, and 403 errors name them too. The default value is `off`. The check runs in the muxed provider server, so it covers the resources of both the plugin framework and SDK implementations.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						providerdata.TokenScopeCheckOff,
+						providerdata.TokenScopeCheckWarn,
+						providerdata.TokenScopeCheckError,
+					),
+				},
+			},
 			"base_url": schema.StringAttribute{
-				MarkdownDescription: "The target Sentry Base API URL in the format `https://[hostname]/api/`. The default value is `https://sentry.io/api/`. The value must be provided when working with Sentry On-Premise. The value can be sourced from the `SENTRY_BASE_URL` environment variable.",
+				MarkdownDescription: "The target Sentry Base API URL in the format `https://[hostname]/api/`. The default value is `https://sentry.io/api/`, in which case the requests for each organization are sent to the API host of the region the organization is hosted in. The value must be provided when working with Sentry On-Premise. The value can be sourced from the `SENTRY_BASE_URL` environment variable.",
+				Optional:            true,
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "The default organization slug for resources and data sources that do not set `organization`. The value can be sourced from the `SENTRY_ORGANIZATION` environment variable.",
+				Optional:            true,
+			},
+			"read_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether to reject every request that would change Sentry, without sending it. Reads, imports and data sources keep working, whatever the scopes of the token. Read-only mode is also enabled when the `SENTRY_READ_ONLY` environment variable is set to `true`. The default value is `false`.",
+				Optional:            true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				MarkdownDescription: "The path of a file to append a JSON line to for every request that changes Sentry. Each line records the time, the Terraform resource type and operation, the HTTP method, path and status code, the Sentry request ID, and the request body with secrets redacted. The file is created if it does not exist.",
+				Optional:            true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "The default value of `deletion_protection` for the resources that support it: `sentry_dashboard`, `sentry_key`, `sentry_organization`, `sentry_project` and `sentry_team`. While it is true, destroying or replacing these resources fails. The default value is `false`.",
+				Optional:            true,
+			},
+			"adopt_on_ambiguous_create": schema.BoolAttribute{
+				MarkdownDescription: "Whether to look up the object of a resource when creating it fails in a way that leaves it unknown whether Sentry created it, such as a timeout, a connection error or a server error. An object matching the resource, such as an alert with the same name in the same project, that was created since is adopted into the state instead of creating a duplicate. Applies to `sentry_dashboard`, `sentry_issue_alert`, `sentry_key`, `sentry_metric_alert` and `sentry_team`. The default value is `true`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of retries for a failed request. The default value is `4`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_backoff": schema.StringAttribute{
+				MarkdownDescription: "The minimum time to wait before retrying a failed request, as a duration string such as `1s`. The default value is `1s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"max_backoff": schema.StringAttribute{
+				MarkdownDescription: "The maximum time to wait before retrying a failed request, as a duration string such as `30s`. The default value is `30s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "The timeout for each request attempt, as a duration string such as `1m`. By default, requests do not time out.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"retry_status_codes": schema.SetAttribute{
+				MarkdownDescription: "The HTTP status codes of responses that are retried. By default, `429` and all `5xx` status codes except `501` are retried. Rate limited (`429`) responses are always retried.",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+			},
+			"max_rate_limit_wait": schema.StringAttribute{
+				MarkdownDescription: "The maximum time to wait for a rate limit to reset before retrying, as a duration string such as `1m`. The default value is `1m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of concurrent requests to Sentry. The provider adapts to the concurrency and rate limits reported by Sentry on each response and never exceeds this value. By default, the concurrency limit reported by Sentry is used, or `10` if Sentry does not report one.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"ca_cert": schema.StringAttribute{
+				MarkdownDescription: "A PEM-encoded CA certificate bundle, or the path to one, used to verify the Sentry server certificate in addition to the system certificate pool. Useful when self-hosting Sentry behind an internal CA.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "A PEM-encoded client certificate, or the path to one, used for mutual TLS authentication. Must be set together with `client_key`.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "A PEM-encoded client private key, or the path to one, used for mutual TLS authentication. Must be set together with `client_cert`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip verification of the Sentry server certificate. This is insecure and should only be used for testing. The default value is `false`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the HTTP(S) proxy used to connect to Sentry, e.g. `http://proxy.example.com:8080`. By default, the proxy is sourced from the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
 				Optional:            true,
 			},
 		},
 	}
 }
 
+func (p *SentryProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		providervalidator.Conflicting(
+			path.MatchRoot("token"),
+			path.MatchRoot("token_file"),
+			path.MatchRoot("token_command"),
+		),
+	}
+}
+
 func (p *SentryProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data SentryProviderModel
 
@@ -60,8 +231,29 @@ func (p *SentryProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	var token string
+	var tokenSource oauth2.TokenSource
 	if !data.Token.IsNull() {
 		token = data.Token.ValueString()
+	} else if !data.TokenFile.IsNull() {
+		v, err := sentryclient.ReadTokenFile(data.TokenFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("token_file"), "Invalid token file", err.Error())
+			return
+		}
+		token = v
+	} else if !data.TokenCommand.IsNull() {
+		var args []string
+		resp.Diagnostics.Append(data.TokenCommand.ElementsAs(ctx, &args, false)...)
+		ttl := parseDuration(data.TokenCommandTtl, path.Root("token_command_ttl"), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		tokenSource = sentryclient.NewCommandTokenSource(args, ttl)
+		if _, err := tokenSource.Token(); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("token_command"), "Failed to run token command", err.Error())
+			return
+		}
 	} else if v := os.Getenv("SENTRY_AUTH_TOKEN"); v != "" {
 		token = v
 	} else if v := os.Getenv("SENTRY_TOKEN"); v != "" {
@@ -73,23 +265,107 @@ func (p *SentryProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		baseUrl = data.BaseUrl.ValueString()
 	} else if v := os.Getenv("SENTRY_BASE_URL"); v != "" {
 		baseUrl = v
-	} else {
-		baseUrl = "https://sentry.io/api/"
+	}
+
+	var organization string
+	if !data.Organization.IsNull() {
+		organization = data.Organization.ValueString()
+	} else if v := os.Getenv("SENTRY_ORGANIZATION"); v != "" {
+		organization = v
+	}
+
+	readOnly := data.ReadOnly.ValueBool()
+	if v := os.Getenv("SENTRY_READ_ONLY"); v != "" {
+		envReadOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid SENTRY_READ_ONLY environment variable", err.Error())
+			return
+		}
+		readOnly = readOnly || envReadOnly
+	}
+
+	// Fall back to the sentry-cli configuration files
+	if err := sentryclient.ApplyCliConfig(&token, tokenSource, &baseUrl, &organization); err != nil {
+		resp.Diagnostics.AddError("failed to load .sentryclirc", err.Error())
+		return
 	}
 
 	config := sentryclient.Config{
-		UserAgent: fmt.Sprintf("Terraform/%s (+https://www.terraform.io) terraform-provider-sentry/%s", req.TerraformVersion, p.version),
-		Token:     token,
-		BaseURL:   baseUrl,
+		UserAgent:   fmt.Sprintf("Terraform/%s (+https://www.terraform.io) terraform-provider-sentry/%s", req.TerraformVersion, p.version),
+		Token:       token,
+		TokenSource: tokenSource,
+		BaseURL:     baseUrl,
+		Cache:       sentryclient.SharedCache(baseUrl, token, tokenSource),
+		ReadOnly:    readOnly,
+	}
+
+	config.CACert = data.CACert.ValueString()
+	config.ClientCert = data.ClientCert.ValueString()
+	config.ClientKey = data.ClientKey.ValueString()
+	config.InsecureSkipVerify = data.InsecureSkipVerify.ValueBool()
+	config.ProxyURL = data.ProxyUrl.ValueString()
+	config.MaxConcurrentRequests = int(data.MaxConcurrentRequests.ValueInt64())
+
+	if !data.MaxRetries.IsNull() {
+		config.MaxRetries = sentry.Int(int(data.MaxRetries.ValueInt64()))
 	}
+	config.MinBackoff = parseDuration(data.MinBackoff, path.Root("min_backoff"), &resp.Diagnostics)
+	config.MaxBackoff = parseDuration(data.MaxBackoff, path.Root("max_backoff"), &resp.Diagnostics)
+	config.RequestTimeout = parseDuration(data.RequestTimeout, path.Root("request_timeout"), &resp.Diagnostics)
+	config.MaxRateLimitWait = parseDuration(data.MaxRateLimitWait, path.Root("max_rate_limit_wait"), &resp.Diagnostics)
+	if !data.RetryStatusCodes.IsNull() {
+		var retryStatusCodes []int64
+		resp.Diagnostics.Append(data.RetryStatusCodes.ElementsAs(ctx, &retryStatusCodes, false)...)
+		for _, code := range retryStatusCodes {
+			config.RetryStatusCodes = append(config.RetryStatusCodes, int(code))
+		}
+	}
+
+	if !data.AuditLogPath.IsNull() {
+		auditLog, err := sentryclient.OpenAuditLog(data.AuditLogPath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("audit_log_path"), "Unable to open audit log", err.Error())
+		}
+		config.AuditLog = auditLog
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	client, err := config.Client(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("failed to create Sentry client", err.Error())
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &providerdata.ProviderData{
+		Client:              client,
+		DefaultOrganization: organization,
+		Cache:               config.Cache,
+		TokenScopeCheck:     providerdata.TokenScopeCheckOff,
+
+		DeletionProtection:     data.DeletionProtection.ValueBool(),
+		AdoptOnAmbiguousCreate: data.AdoptOnAmbiguousCreate.IsNull() || data.AdoptOnAmbiguousCreate.ValueBool(),
+	}
+
+	if v := data.TokenScopeCheck.ValueString(); v != "" && v != providerdata.TokenScopeCheckOff {
+		tokenInfo, err := config.IntrospectToken(ctx, client)
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("token_scope_check"),
+				"Unable to check token scopes",
+				fmt.Sprintf("The scopes of the token could not be read, so they will not be checked: %s", err),
+			)
+		} else {
+			providerData.TokenScopeCheck = v
+			providerData.TokenInfo = tokenInfo
+		}
+	}
+	p.data.Store(providerData)
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 func (p *SentryProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -100,7 +376,10 @@ func (p *SentryProvider) Resources(ctx context.Context) []func() resource.Resour
 		NewIntegrationPagerDuty,
 		NewIssueAlertResource,
 		NewNotificationActionResource,
+		NewOrganizationDataScrubbingRuleResource,
+		NewProjectDataScrubbingRuleResource,
 		NewProjectInboundDataFilterResource,
+		NewProjectOwnershipResource,
 		NewProjectSpikeProtectionResource,
 		NewProjectSymbolSourcesResource,
 		NewTeamMemberResource,
@@ -119,6 +398,21 @@ func (p *SentryProvider) DataSources(ctx context.Context) []func() datasource.Da
 	}
 }
 
+// parseDuration parses an optional duration attribute, returning zero if it is
+// not set.
+func parseDuration(value types.String, p path.Path, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return 0
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid duration", err.Error())
+		return 0
+	}
+	return d
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &SentryProvider{
@@ -24,8 +24,9 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 			context.Background(),
 			sentry.NewProvider(acctest.ProviderVersion)().GRPCProvider,
 		))
+		sentryProvider := New(acctest.ProviderVersion)()
 		providers := []func() tfprotov6.ProviderServer{
-			providerserver.NewProtocol6(New(acctest.ProviderVersion)()),
+			providerserver.NewProtocol6(sentryProvider),
 			func() tfprotov6.ProviderServer {
 				return upgradedSdkProvider
 			},
@@ -37,6 +38,6 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 			return nil, err
 		}
 
-		return muxServer.ProviderServer(), nil
+		return NewOperationServer(muxServer.ProviderServer(), sentryProvider), nil
 	},
 }
@@ -3,13 +3,23 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
 )
 
 type baseResource struct {
-	client *sentry.Client
+	providerData        *providerdata.ProviderData
+	client              *sentry.Client
+	defaultOrganization string
+	cache               *sentryclient.Cache
 }
 
 func (r *baseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -18,16 +28,182 @@ func (r *baseResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*sentry.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sentry.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
+	r.client = data.ClientFor(ctx)
+	r.defaultOrganization = data.DefaultOrganization
+	r.cache = data.Cache
+}
+
+// ModifyPlan plans the provider-level defaults of `organization` and
+// `deletion_protection` for resources that do not set them, and replaces the
+// objects of resources moved to another organization. Protected objects fail
+// to plan their destruction or replacement, rather than failing halfway
+// through the apply.
+//
+// Resources with their own ModifyPlan call it once they have added the
+// attributes that require replacing their objects to resp.RequiresReplace.
+func (r *baseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do before the provider has been configured.
+	if r.client == nil {
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() {
+		r.modifyPlanOrganization(ctx, req, resp)
+		r.modifyPlanDeletionProtection(ctx, req, resp)
+	}
+	if req.Plan.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		r.modifyPlanDestroy(ctx, req, resp)
+	}
+}
+
+func (r *baseResource) modifyPlanOrganization(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if _, ok := req.Config.Schema.GetAttributes()["organization"]; !ok {
+		return
+	}
+
+	var organization types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("organization"), &organization)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if organization.IsNull() {
+		if r.defaultOrganization == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("organization"),
+				"Missing organization",
+				"The organization must be set either on the resource or on the provider (or via the SENTRY_ORGANIZATION environment variable).",
+			)
+			return
+		}
+
+		organization = types.StringValue(r.defaultOrganization)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("organization"), organization)...)
+	}
+
+	// Objects cannot be moved to another organization. This is not a plan
+	// modifier of the attribute, as the default organization is only planned
+	// here.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var stateOrganization types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("organization"), &stateOrganization)...)
+	if !stateOrganization.IsNull() && !organization.Equal(stateOrganization) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("organization"))
+	}
+}
+
+func (r *baseResource) modifyPlanDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if _, ok := req.Config.Schema.GetAttributes()["deletion_protection"]; !ok || r.providerData == nil {
+		return
+	}
+
+	var deletionProtection types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if resp.Diagnostics.HasError() || !deletionProtection.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deletion_protection"), r.providerData.DeletionProtection)...)
+}
+
+// modifyPlanDestroy adds an error if the object of a resource planned to be
+// destroyed or replaced is protected from being destroyed.
+func (r *baseResource) modifyPlanDestroy(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+	if _, ok := req.State.Schema.GetAttributes()["deletion_protection"]; !ok {
+		return
+	}
+
+	var deletionProtection types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.checkDeletionProtection(&resp.Diagnostics, "object", deletionProtection)
+}
+
+// checkDeletionProtection adds an error if the object of a resource is
+// protected from being destroyed, which also prevents its replacement. The
+// provider-level default applies to objects imported or created before the
+// attribute existed.
+func (r *baseResource) checkDeletionProtection(diags *diag.Diagnostics, name string, deletionProtection types.Bool) {
+	protected := deletionProtection.ValueBool()
+	if deletionProtection.IsNull() && r.providerData != nil {
+		protected = r.providerData.DeletionProtection
+	}
+	if !protected {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion protection is enabled",
+		fmt.Sprintf("The %s cannot be destroyed or replaced while `deletion_protection` is true. Set `deletion_protection` to false and apply before destroying it.", name),
+	)
+}
+
+// addReadError adds the error of reading the object of a resource. An object
+// that no longer exists is removed from the state instead, see removeNotFound.
+func addReadError(ctx context.Context, diags *diag.Diagnostics, state *tfsdk.State, name string, err error) {
+	if sentryclient.IsNotFound(err) {
+		removeNotFound(ctx, diags, state, name)
+		return
+	}
+
+	diags.AddError("Client Error", fmt.Sprintf("Error reading %s: %s", name, err.Error()))
+}
+
+// removeNotFound removes the object of a resource that no longer exists from
+// the state with a warning, so that Terraform plans to create it again.
+func removeNotFound(ctx context.Context, diags *diag.Diagnostics, state *tfsdk.State, name string) {
+	diags.AddWarning(
+		"Resource not found",
+		fmt.Sprintf("The %s no longer exists in Sentry and has been removed from the state.", name),
+	)
+	state.RemoveResource(ctx)
+}
+
+// addDeleteError adds the error of deleting the object of a resource, unless
+// the object no longer exists.
+func addDeleteError(diags *diag.Diagnostics, name string, err error) {
+	if sentryclient.IsNotFound(err) {
+		return
+	}
+
+	diags.AddError("Client Error", fmt.Sprintf("Error deleting %s: %s", name, err.Error()))
+}
+
+// withTimeout returns ctx with the timeout of an operation, read from the
+// `timeouts` block with one of the methods of timeouts.Value, such as
+// `data.Timeouts.Create`. The timeout defaults to defaultTimeout.
+//
+// The client of the resource is bound to the returned context, so that its
+// requests, retries and rate limit waits end with the operation.
+func (r *baseResource) withTimeout(ctx context.Context, diags *diag.Diagnostics, timeout func(context.Context, time.Duration) (time.Duration, diag.Diagnostics), defaultTimeout time.Duration) (context.Context, context.CancelFunc) {
+	d, timeoutDiags := timeout(ctx, defaultTimeout)
+	diags.Append(timeoutDiags...)
+
+	ctx, cancel := context.WithTimeout(ctx, d)
+	if r.providerData != nil {
+		r.client = r.providerData.ClientFor(ctx)
+	}
+	return ctx, cancel
 }
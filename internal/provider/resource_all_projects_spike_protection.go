@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -11,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 var _ resource.Resource = &AllProjectsSpikeProtectionResource{}
@@ -25,9 +27,10 @@ type AllProjectsSpikeProtectionResource struct {
 }
 
 type AllProjectsSpikeProtectionResourceModel struct {
-	Organization types.String `tfsdk:"organization"`
-	Enabled      types.Bool   `tfsdk:"enabled"`
-	Projects     types.Set    `tfsdk:"projects"`
+	Organization types.String   `tfsdk:"organization"`
+	Enabled      types.Bool     `tfsdk:"enabled"`
+	Projects     types.Set      `tfsdk:"projects"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (m *AllProjectsSpikeProtectionResourceModel) Fill(organization string, enabled bool, projects []sentry.Project) error {
@@ -53,8 +56,9 @@ func (r *AllProjectsSpikeProtectionResource) Schema(ctx context.Context, req res
 
 		Attributes: map[string]schema.Attribute{
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"projects": schema.SetAttribute{
 				MarkdownDescription: "The slugs of the projects to enable or disable spike protection for.",
@@ -69,37 +73,29 @@ func (r *AllProjectsSpikeProtectionResource) Schema(ctx context.Context, req res
 				Required:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
 func (r *AllProjectsSpikeProtectionResource) readProjects(ctx context.Context, organization string, enabled bool, projectSlugs []string) ([]sentry.Project, error) {
-	var allProjects []sentry.Project
-	params := &sentry.ListOrganizationProjectsParams{
-		Options: "quotas:spike-protection-disabled",
+	projects, err := r.cache.Projects(ctx, r.providerData.Client, organization)
+	if err != nil {
+		return nil, err
 	}
 
-	for {
-		projects, apiResp, err := r.client.OrganizationProjects.List(ctx, organization, params)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, project := range projects {
-			for _, projectSlug := range projectSlugs {
-				if projectSlug == project.Slug {
-					if projectDisabled, ok := project.Options["quotas:spike-protection-disabled"].(bool); ok && projectDisabled != enabled {
-						allProjects = append(allProjects, *project)
-					}
-
-					break
+	var allProjects []sentry.Project
+	for _, project := range projects {
+		for _, projectSlug := range projectSlugs {
+			if projectSlug == project.Slug {
+				if projectDisabled, ok := project.Options["quotas:spike-protection-disabled"].(bool); ok && projectDisabled != enabled {
+					allProjects = append(allProjects, *project)
 				}
-			}
-		}
 
-		if apiResp.Cursor == "" {
-			break
+				break
+			}
 		}
-		params.Cursor = apiResp.Cursor
 	}
 
 	return allProjects, nil
@@ -113,6 +109,9 @@ func (r *AllProjectsSpikeProtectionResource) Create(ctx context.Context, req res
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
 	projects := []string{}
 	if !data.Projects.IsNull() {
 		resp.Diagnostics.Append(data.Projects.ElementsAs(ctx, &projects, false)...)
@@ -127,7 +126,7 @@ func (r *AllProjectsSpikeProtectionResource) Create(ctx context.Context, req res
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error enabling spike protection: %s", err.Error()))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error enabling spike protection", err)
 			return
 		}
 	} else {
@@ -139,7 +138,7 @@ func (r *AllProjectsSpikeProtectionResource) Create(ctx context.Context, req res
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error disabling spike protection: %s", err.Error()))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error disabling spike protection", err)
 			return
 		}
 	}
@@ -166,6 +165,9 @@ func (r *AllProjectsSpikeProtectionResource) Read(ctx context.Context, req resou
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
 	projects := []string{}
 	if !data.Projects.IsNull() {
 		resp.Diagnostics.Append(data.Projects.ElementsAs(ctx, &projects, false)...)
@@ -173,7 +175,7 @@ func (r *AllProjectsSpikeProtectionResource) Read(ctx context.Context, req resou
 
 	allProjects, err := r.readProjects(ctx, data.Organization.ValueString(), data.Enabled.ValueBool(), projects)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err))
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "spike protection", err)
 		return
 	}
 
@@ -193,6 +195,9 @@ func (r *AllProjectsSpikeProtectionResource) Update(ctx context.Context, req res
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
 	projects := []string{}
 	if !data.Projects.IsNull() {
 		resp.Diagnostics.Append(data.Projects.ElementsAs(ctx, &projects, false)...)
@@ -207,7 +212,7 @@ func (r *AllProjectsSpikeProtectionResource) Update(ctx context.Context, req res
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error enabling spike protection: %s", err.Error()))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error enabling spike protection", err)
 			return
 		}
 	} else {
@@ -219,7 +224,7 @@ func (r *AllProjectsSpikeProtectionResource) Update(ctx context.Context, req res
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error disabling spike protection: %s", err.Error()))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error disabling spike protection", err)
 			return
 		}
 	}
@@ -246,6 +251,9 @@ func (r *AllProjectsSpikeProtectionResource) Delete(ctx context.Context, req res
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
 	projects := []string{}
 	if !data.Projects.IsNull() {
 		resp.Diagnostics.Append(data.Projects.ElementsAs(ctx, &projects, false)...)
@@ -261,7 +269,7 @@ func (r *AllProjectsSpikeProtectionResource) Delete(ctx context.Context, req res
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error disabling spike protection: %s", err.Error()))
+			addDeleteError(&resp.Diagnostics, "spike protection", err)
 			return
 		}
 	} else {
@@ -274,7 +282,7 @@ func (r *AllProjectsSpikeProtectionResource) Delete(ctx context.Context, req res
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error enabling spike protection: %s", err.Error()))
+			addDeleteError(&resp.Diagnostics, "spike protection", err)
 			return
 		}
 	}
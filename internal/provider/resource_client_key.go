@@ -3,8 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -13,12 +14,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 var _ resource.Resource = &ClientKeyResource{}
 var _ resource.ResourceWithConfigure = &ClientKeyResource{}
 var _ resource.ResourceWithConfigValidators = &ClientKeyResource{}
 var _ resource.ResourceWithImportState = &ClientKeyResource{}
+var _ resource.ResourceWithModifyPlan = &ClientKeyResource{}
 
 func NewClientKeyResource() resource.Resource {
 	return &ClientKeyResource{}
@@ -29,18 +32,26 @@ type ClientKeyResource struct {
 }
 
 type ClientKeyResourceModel struct {
-	Id              types.String `tfsdk:"id"`
-	Organization    types.String `tfsdk:"organization"`
-	Project         types.String `tfsdk:"project"`
-	ProjectId       types.String `tfsdk:"project_id"`
-	Name            types.String `tfsdk:"name"`
-	RateLimitWindow types.Int64  `tfsdk:"rate_limit_window"`
-	RateLimitCount  types.Int64  `tfsdk:"rate_limit_count"`
-	Public          types.String `tfsdk:"public"`
-	Secret          types.String `tfsdk:"secret"`
-	DsnPublic       types.String `tfsdk:"dsn_public"`
-	DsnSecret       types.String `tfsdk:"dsn_secret"`
-	DsnCsp          types.String `tfsdk:"dsn_csp"`
+	Id                 types.String   `tfsdk:"id"`
+	Organization       types.String   `tfsdk:"organization"`
+	Project            types.String   `tfsdk:"project"`
+	ProjectId          types.String   `tfsdk:"project_id"`
+	Name               types.String   `tfsdk:"name"`
+	RateLimitWindow    types.Int64    `tfsdk:"rate_limit_window"`
+	RateLimitCount     types.Int64    `tfsdk:"rate_limit_count"`
+	Public             types.String   `tfsdk:"public"`
+	Secret             types.String   `tfsdk:"secret"`
+	DsnPublic          types.String   `tfsdk:"dsn_public"`
+	DsnSecret          types.String   `tfsdk:"dsn_secret"`
+	DsnCsp             types.String   `tfsdk:"dsn_csp"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// clientKeyFieldPaths maps the fields of client key requests to attributes.
+var clientKeyFieldPaths = map[string]string{
+	"rateLimit.window": "rate_limit_window",
+	"rateLimit.count":  "rate_limit_count",
 }
 
 func (m *ClientKeyResourceModel) Fill(organization string, project string, key sentry.ProjectKey) error {
@@ -93,8 +104,9 @@ func (r *ClientKeyResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"project": schema.StringAttribute{
 				MarkdownDescription: "The slug of the project the resource belongs to.",
@@ -136,10 +148,37 @@ func (r *ClientKeyResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Security header endpoint for features like CSP and Expect-CT reports.",
 				Computed:            true,
 			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform is prevented from destroying or replacing the resource. Set it to `false` and apply before destroying the resource. Defaults to the provider-level `deletion_protection`.",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
 
+func (r *ClientKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Client keys cannot be moved to another project. This is not a plan
+	// modifier of the attribute, so that deletion protection also applies to
+	// the replacement.
+	if !req.Plan.Raw.IsNull() && !req.State.Raw.IsNull() {
+		var project, stateProject types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project"), &project)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("project"), &stateProject)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !project.Equal(stateProject) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("project"))
+		}
+	}
+
+	r.baseResource.ModifyPlan(ctx, req, resp)
+}
+
 func (r *ClientKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ClientKeyResourceModel
 
@@ -148,6 +187,9 @@ func (r *ClientKeyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
 	params := &sentry.CreateProjectKeyParams{
 		Name: data.Name.ValueString(),
 		RateLimit: &sentry.ProjectKeyRateLimit{
@@ -156,14 +198,42 @@ func (r *ClientKeyResource) Create(ctx context.Context, req resource.CreateReque
 		},
 	}
 
-	key, _, err := r.client.ProjectKeys.Create(
+	key, err := providerdata.CreateOnce(
 		ctx,
-		data.Organization.ValueString(),
-		data.Project.ValueString(),
-		params,
+		r.providerData,
+		"client key",
+		func(client *sentry.Client) (*sentry.ProjectKey, error) {
+			key, _, err := client.ProjectKeys.Create(
+				ctx,
+				data.Organization.ValueString(),
+				data.Project.ValueString(),
+				params,
+			)
+			return key, err
+		},
+		func(client *sentry.Client, since time.Time) (*sentry.ProjectKey, error) {
+			params := &sentry.ListProjectKeysParams{}
+			for {
+				keys, apiResp, err := client.ProjectKeys.List(ctx, data.Organization.ValueString(), data.Project.ValueString(), params)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, key := range keys {
+					if key.Name == data.Name.ValueString() && !key.DateCreated.Before(since) {
+						return key, nil
+					}
+				}
+
+				if apiResp.Cursor == "" {
+					return nil, nil
+				}
+				params.Cursor = apiResp.Cursor
+			}
+		},
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Create error: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, clientKeyFieldPaths, "Create error", err)
 		return
 	}
 	if err := data.Fill(data.Organization.ValueString(), data.Project.ValueString(), *key); err != nil {
@@ -186,19 +256,17 @@ func (r *ClientKeyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	key, apiResp, err := r.client.ProjectKeys.Get(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	key, _, err := r.client.ProjectKeys.Get(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 		data.Id.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err.Error()))
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "client key", err)
 		return
 	}
 
@@ -207,6 +275,11 @@ func (r *ClientKeyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// Imported keys do not have a deletion protection yet
+	if data.DeletionProtection.IsNull() && r.providerData != nil {
+		data.DeletionProtection = types.BoolValue(r.providerData.DeletionProtection)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -218,6 +291,9 @@ func (r *ClientKeyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
 	params := &sentry.UpdateProjectKeyParams{
 		Name: data.Name.ValueString(),
 		RateLimit: &sentry.ProjectKeyRateLimit{
@@ -226,20 +302,15 @@ func (r *ClientKeyResource) Update(ctx context.Context, req resource.UpdateReque
 		},
 	}
 
-	key, apiResp, err := r.client.ProjectKeys.Update(
+	key, _, err := r.client.ProjectKeys.Update(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 		data.Id.ValueString(),
 		params,
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Update error: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, clientKeyFieldPaths, "Update error", err)
 		return
 	}
 
@@ -259,18 +330,22 @@ func (r *ClientKeyResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	apiResp, err := r.client.ProjectKeys.Delete(
+	r.checkDeletionProtection(&resp.Diagnostics, "client key", data.DeletionProtection)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	_, err := r.client.ProjectKeys.Delete(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 		data.Id.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		return
-	}
-
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Delete error: %s", err.Error()))
+		addDeleteError(&resp.Diagnostics, "client key", err)
 		return
 	}
 }
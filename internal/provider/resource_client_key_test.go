@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -147,6 +148,97 @@ func TestAccClientKeyResource(t *testing.T) {
 	})
 }
 
+func TestAccClientKeyResource_DefaultOrganization(t *testing.T) {
+	teamName := acctest.RandomWithPrefix("tf-team")
+	projectName := acctest.RandomWithPrefix("tf-project")
+	keyName := acctest.RandomWithPrefix("tf-key")
+	rn := "sentry_key.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "sentry" {
+	organization = "%[1]s"
+}
+
+resource "sentry_team" "test" {
+	name = "%[2]s"
+	slug = "%[2]s"
+}
+
+resource "sentry_project" "test" {
+	teams    = [sentry_team.test.id]
+	name     = "%[3]s"
+	platform = "go"
+}
+
+resource "sentry_key" "test" {
+	project = sentry_project.test.id
+	name    = "%[4]s"
+}
+`, acctest.TestOrganization, teamName, projectName, keyName),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("sentry_team.test", tfjsonpath.New("organization"), knownvalue.StringExact(acctest.TestOrganization)),
+					statecheck.ExpectKnownValue("sentry_project.test", tfjsonpath.New("organization"), knownvalue.StringExact(acctest.TestOrganization)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("organization"), knownvalue.StringExact(acctest.TestOrganization)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("name"), knownvalue.StringExact(keyName)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccClientKeyResource_DeletionProtection(t *testing.T) {
+	teamName := acctest.RandomWithPrefix("tf-team")
+	projectName := acctest.RandomWithPrefix("tf-project")
+	keyName := acctest.RandomWithPrefix("tf-key")
+	rn := "sentry_key.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClientKeyResourceConfig(teamName, projectName, keyName, `
+	deletion_protection = true
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("deletion_protection"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				Config: testAccProjectResourceConfig(teamName, projectName) + fmt.Sprintf(`
+resource "sentry_key" "test" {
+	organization        = sentry_project.test.organization
+	project             = "${sentry_project.test.id}-moved"
+	name                = "%[1]s"
+	deletion_protection = true
+}
+`, keyName),
+				ExpectError: regexp.MustCompile("Deletion protection is enabled"),
+			},
+			{
+				Config: testAccClientKeyResourceConfig(teamName, projectName, keyName, `
+	deletion_protection = true
+				`),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Deletion protection is enabled"),
+			},
+			{
+				Config: testAccClientKeyResourceConfig(teamName, projectName, keyName, `
+	deletion_protection = false
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("deletion_protection"), knownvalue.Bool(false)),
+				},
+			},
+		},
+	})
+}
+
 func testAccClientKeyResourceConfig(teamName, projectName, keyName, extras string) string {
 	return testAccProjectResourceConfig(teamName, projectName) + fmt.Sprintf(`
 resource "sentry_key" "test" {
@@ -4,14 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 var _ resource.Resource = &IntegrationOpsgenie{}
@@ -27,11 +28,12 @@ type IntegrationOpsgenie struct {
 }
 
 type IntegrationOpsgenieModel struct {
-	Id             types.String `tfsdk:"id"`
-	Organization   types.String `tfsdk:"organization"`
-	IntegrationId  types.String `tfsdk:"integration_id"`
-	Team           types.String `tfsdk:"team"`
-	IntegrationKey types.String `tfsdk:"integration_key"`
+	Id             types.String   `tfsdk:"id"`
+	Organization   types.String   `tfsdk:"organization"`
+	IntegrationId  types.String   `tfsdk:"integration_id"`
+	Team           types.String   `tfsdk:"team"`
+	IntegrationKey types.String   `tfsdk:"integration_key"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (m *IntegrationOpsgenieModel) Fill(organization string, integrationId string, item IntegrationOpsgenieConfigDataTeamTableItem) error {
@@ -71,12 +73,16 @@ func (r *IntegrationOpsgenie) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"integration_id": schema.StringAttribute{
 				MarkdownDescription: "The ID of the Opsgenie integration. Source from the URL `https://<organization>.sentry.io/settings/integrations/opsgenie/<integration-id>/` or use the `sentry_organization_integration` data source.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"team": schema.StringAttribute{
 				MarkdownDescription: "The name of the Opsgenie team. In Sentry, this is called Label.",
@@ -87,6 +93,9 @@ func (r *IntegrationOpsgenie) Schema(ctx context.Context, req resource.SchemaReq
 				Required:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -98,11 +107,10 @@ func (r *IntegrationOpsgenie) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	integration, apiResp, err := r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Not found: %s", err.Error()))
-		return
-	}
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
+	integration, _, err := r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err.Error()))
 		return
@@ -139,15 +147,11 @@ func (r *IntegrationOpsgenie) Create(ctx context.Context, req resource.CreateReq
 		&params,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Create error: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Create error", err)
 		return
 	}
 
-	integration, apiResp, err = r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Not found: %s", err.Error()))
-		return
-	}
+	integration, _, err = r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err.Error()))
 		return
@@ -189,14 +193,12 @@ func (r *IntegrationOpsgenie) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	integration, apiResp, err := r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	integration, _, err := r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err.Error()))
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "Opsgenie integration", err)
 		return
 	}
 
@@ -214,8 +216,7 @@ func (r *IntegrationOpsgenie) Read(ctx context.Context, req resource.ReadRequest
 		}
 	}
 	if found == nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Team table item not found: %s", data.IntegrationId.ValueString()))
-		resp.State.RemoveResource(ctx)
+		removeNotFound(ctx, &resp.Diagnostics, &resp.State, "Opsgenie integration")
 		return
 	}
 
@@ -235,10 +236,10 @@ func (r *IntegrationOpsgenie) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	integration, apiResp, err := r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
-	if apiResp.StatusCode == http.StatusNotFound {
-		return
-	}
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
+	integration, _, err := r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err.Error()))
 		return
@@ -281,16 +282,11 @@ func (r *IntegrationOpsgenie) Update(ctx context.Context, req resource.UpdateReq
 		&params,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Update error: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Update error", err)
 		return
 	}
 
-	integration, apiResp, err = r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
+	integration, _, err = r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err.Error()))
 		return
@@ -330,12 +326,12 @@ func (r *IntegrationOpsgenie) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	integration, apiResp, err := r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
-	if apiResp.StatusCode == http.StatusNotFound {
-		return
-	}
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	integration, _, err := r.client.OrganizationIntegrations.Get(ctx, data.Organization.ValueString(), data.IntegrationId.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Read error: %s", err.Error()))
+		addDeleteError(&resp.Diagnostics, "Opsgenie integration", err)
 		return
 	}
 
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
 	"github.com/jianyuan/terraform-provider-sentry/internal/pkg/must"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 	"github.com/jianyuan/terraform-provider-sentry/internal/sentrytypes"
 )
 
@@ -45,6 +48,12 @@ type IssueAlertResourceModel struct {
 	Frequency    types.Int64           `tfsdk:"frequency"`
 	Environment  types.String          `tfsdk:"environment"`
 	Owner        types.String          `tfsdk:"owner"`
+	Timeouts     timeouts.Value        `tfsdk:"timeouts"`
+}
+
+// issueAlertFieldPaths maps the fields of issue alert requests to attributes.
+var issueAlertFieldPaths = map[string]string{
+	"projects": "project",
 }
 
 func (m *IssueAlertResourceModel) Fill(organization string, alert sentry.IssueAlert) error {
@@ -120,12 +129,16 @@ Please note the following changes since v0.12.0:
 				},
 			},
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the resource belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the resource belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"project": schema.StringAttribute{
 				MarkdownDescription: "The slug of the project the resource belongs to.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The issue alert name.",
@@ -176,6 +189,9 @@ Please note the following changes since v0.12.0:
 				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -188,6 +204,9 @@ func (r *IssueAlertResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
 	params := &sentry.IssueAlert{
 		Name:        data.Name.ValueStringPointer(),
 		ActionMatch: data.ActionMatch.ValueStringPointer(),
@@ -211,14 +230,42 @@ func (r *IssueAlertResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	action, _, err := r.client.IssueAlerts.Create(
+	action, err := providerdata.CreateOnce(
 		ctx,
-		data.Organization.ValueString(),
-		data.Project.ValueString(),
-		params,
+		r.providerData,
+		"issue alert",
+		func(client *sentry.Client) (*sentry.IssueAlert, error) {
+			action, _, err := client.IssueAlerts.Create(
+				ctx,
+				data.Organization.ValueString(),
+				data.Project.ValueString(),
+				params,
+			)
+			return action, err
+		},
+		func(client *sentry.Client, since time.Time) (*sentry.IssueAlert, error) {
+			params := &sentry.ListCursorParams{}
+			for {
+				actions, apiResp, err := client.IssueAlerts.List(ctx, data.Organization.ValueString(), data.Project.ValueString(), params)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, action := range actions {
+					if sentry.StringValue(action.Name) == data.Name.ValueString() && action.DateCreated != nil && !action.DateCreated.Before(since) {
+						return action, nil
+					}
+				}
+
+				if apiResp.Cursor == "" {
+					return nil, nil
+				}
+				params.Cursor = apiResp.Cursor
+			}
+		},
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating issue alert: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, issueAlertFieldPaths, "Error creating issue alert", err)
 		return
 	}
 
@@ -243,19 +290,17 @@ func (r *IssueAlertResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	action, apiResp, err := r.client.IssueAlerts.Get(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	action, _, err := r.client.IssueAlerts.Get(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 		data.Id.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Issue alert not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading issue alert: %s", err.Error()))
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "issue alert", err)
 		return
 	}
 
@@ -276,6 +321,9 @@ func (r *IssueAlertResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
 	params := &sentry.IssueAlert{
 		Name:        data.Name.ValueStringPointer(),
 		ActionMatch: data.ActionMatch.ValueStringPointer(),
@@ -299,20 +347,15 @@ func (r *IssueAlertResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	action, apiResp, err := r.client.IssueAlerts.Update(
+	action, _, err := r.client.IssueAlerts.Update(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 		data.Id.ValueString(),
 		params,
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Notification Action not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating notification action: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, issueAlertFieldPaths, "Error updating issue alert", err)
 		return
 	}
 
@@ -333,18 +376,17 @@ func (r *IssueAlertResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	apiResp, err := r.client.IssueAlerts.Delete(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	_, err := r.client.IssueAlerts.Delete(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 		data.Id.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		return
-	}
-
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting issue alert: %s", err.Error()))
+		addDeleteError(&resp.Diagnostics, "issue alert", err)
 		return
 	}
 }
@@ -458,6 +500,14 @@ func (r *IssueAlertResource) UpgradeState(ctx context.Context) map[int64]resourc
 					FilterMatch:  priorStateData.FilterMatch,
 					Frequency:    priorStateData.Frequency,
 					Environment:  priorStateData.Environment,
+					Timeouts: timeouts.Value{
+						Object: types.ObjectNull(map[string]attr.Type{
+							"create": types.StringType,
+							"read":   types.StringType,
+							"update": types.StringType,
+							"delete": types.StringType,
+						}),
+					},
 				}
 
 				upgradedStateData.Conditions = sentrytypes.NewLossyJsonNull()
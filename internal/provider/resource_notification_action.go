@@ -4,9 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
 )
 
@@ -33,14 +34,15 @@ type NotificationActionResource struct {
 }
 
 type NotificationActionResourceModel struct {
-	Id               types.String `tfsdk:"id"`
-	Organization     types.String `tfsdk:"organization"`
-	TriggerType      types.String `tfsdk:"trigger_type"`
-	ServiceType      types.String `tfsdk:"service_type"`
-	IntegrationId    types.String `tfsdk:"integration_id"`
-	TargetIdentifier types.String `tfsdk:"target_identifier"`
-	TargetDisplay    types.String `tfsdk:"target_display"`
-	Projects         types.List   `tfsdk:"projects"`
+	Id               types.String   `tfsdk:"id"`
+	Organization     types.String   `tfsdk:"organization"`
+	TriggerType      types.String   `tfsdk:"trigger_type"`
+	ServiceType      types.String   `tfsdk:"service_type"`
+	IntegrationId    types.String   `tfsdk:"integration_id"`
+	TargetIdentifier types.String   `tfsdk:"target_identifier"`
+	TargetDisplay    types.String   `tfsdk:"target_display"`
+	Projects         types.List     `tfsdk:"projects"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (m *NotificationActionResourceModel) Fill(action sentry.NotificationAction, projectIdToSlugMap map[string]string) error {
@@ -99,8 +101,9 @@ func (r *NotificationActionResource) Schema(ctx context.Context, req resource.Sc
 				},
 			},
 			"organization": schema.StringAttribute{
-				Description: "The slug of the organization the project belongs to.",
-				Required:    true,
+				Description: "The slug of the organization the project belongs to. Defaults to the provider-level `organization`.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"trigger_type": schema.StringAttribute{
 				Description: "The type of trigger that will activate this action. Valid values are `spike-protection`.",
@@ -134,6 +137,9 @@ func (r *NotificationActionResource) Schema(ctx context.Context, req resource.Sc
 				ElementType: types.StringType,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -146,6 +152,9 @@ func (r *NotificationActionResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
 	projects := []string{}
 	resp.Diagnostics.Append(data.Projects.ElementsAs(ctx, &projects, false)...)
 	if resp.Diagnostics.HasError() {
@@ -165,13 +174,13 @@ func (r *NotificationActionResource) Create(ctx context.Context, req resource.Cr
 		},
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating notification action: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error creating notification action", err)
 		return
 	}
 
 	var projectIdToSlugMap map[string]string
 	if len(action.Projects) > 0 {
-		projectIdToSlugMap, err = sentryclient.GetProjectIdToSlugMap(ctx, r.client)
+		projectIdToSlugMap, err = sentryclient.GetProjectIdToSlugMap(ctx, r.cache, r.providerData.Client, data.Organization.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading projects: %s", err.Error()))
 			return
@@ -195,24 +204,22 @@ func (r *NotificationActionResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	action, apiResp, err := r.client.NotificationActions.Get(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	action, _, err := r.client.NotificationActions.Get(
 		ctx,
 		data.Organization.ValueString(),
 		data.Id.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Notification Action not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading notification action: %s", err.Error()))
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "notification action", err)
 		return
 	}
 
 	var projectIdToSlugMap map[string]string
 	if len(action.Projects) > 0 {
-		projectIdToSlugMap, err = sentryclient.GetProjectIdToSlugMap(ctx, r.client)
+		projectIdToSlugMap, err = sentryclient.GetProjectIdToSlugMap(ctx, r.cache, r.providerData.Client, data.Organization.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading projects: %s", err.Error()))
 			return
@@ -236,13 +243,16 @@ func (r *NotificationActionResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
 	projects := []string{}
 	resp.Diagnostics.Append(data.Projects.ElementsAs(ctx, &projects, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	action, apiResp, err := r.client.NotificationActions.Update(
+	action, _, err := r.client.NotificationActions.Update(
 		ctx,
 		data.Organization.ValueString(),
 		data.Id.ValueString(),
@@ -255,19 +265,14 @@ func (r *NotificationActionResource) Update(ctx context.Context, req resource.Up
 			Projects:         projects,
 		},
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Notification Action not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating notification action: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error updating notification action", err)
 		return
 	}
 
 	var projectIdToSlugMap map[string]string
 	if len(action.Projects) > 0 {
-		projectIdToSlugMap, err = sentryclient.GetProjectIdToSlugMap(ctx, r.client)
+		projectIdToSlugMap, err = sentryclient.GetProjectIdToSlugMap(ctx, r.cache, r.providerData.Client, data.Organization.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading projects: %s", err.Error()))
 			return
@@ -291,17 +296,16 @@ func (r *NotificationActionResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
-	apiResp, err := r.client.NotificationActions.Delete(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	_, err := r.client.NotificationActions.Delete(
 		ctx,
 		data.Organization.ValueString(),
 		data.Id.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		return
-	}
-
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error disabling spike protection: %s", err.Error()))
+		addDeleteError(&resp.Diagnostics, "notification action", err)
 		return
 	}
 }
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+)
+
+var _ resource.Resource = &OrganizationDataScrubbingRuleResource{}
+var _ resource.ResourceWithConfigure = &OrganizationDataScrubbingRuleResource{}
+var _ resource.ResourceWithImportState = &OrganizationDataScrubbingRuleResource{}
+var _ resource.ResourceWithValidateConfig = &OrganizationDataScrubbingRuleResource{}
+
+func NewOrganizationDataScrubbingRuleResource() resource.Resource {
+	return &OrganizationDataScrubbingRuleResource{}
+}
+
+type OrganizationDataScrubbingRuleResource struct {
+	baseResource
+}
+
+type OrganizationDataScrubbingRuleResourceModel struct {
+	Id           types.String   `tfsdk:"id"`
+	Organization types.String   `tfsdk:"organization"`
+	RuleId       types.String   `tfsdk:"rule_id"`
+	Method       types.String   `tfsdk:"method"`
+	Replacement  types.String   `tfsdk:"replacement"`
+	DataType     types.String   `tfsdk:"data_type"`
+	Pattern      types.String   `tfsdk:"pattern"`
+	Source       types.String   `tfsdk:"source"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (m *OrganizationDataScrubbingRuleResourceModel) rule() dataScrubbingRule {
+	return dataScrubbingRule{
+		RuleId:      m.RuleId,
+		Method:      m.Method,
+		Replacement: m.Replacement,
+		DataType:    m.DataType,
+		Pattern:     m.Pattern,
+		Source:      m.Source,
+	}
+}
+
+func (m *OrganizationDataScrubbingRuleResourceModel) Fill(organization string, rule dataScrubbingRule) error {
+	m.Id = types.StringValue(buildTwoPartID(organization, rule.RuleId.ValueString()))
+	m.Organization = types.StringValue(organization)
+	m.RuleId = rule.RuleId
+	m.Method = rule.Method
+	m.Replacement = rule.Replacement
+	m.DataType = rule.DataType
+	m.Pattern = rule.Pattern
+	m.Source = rule.Source
+
+	return nil
+}
+
+func (r *OrganizationDataScrubbingRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_data_scrubbing_rule"
+}
+
+func (r *OrganizationDataScrubbingRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := dataScrubbingRuleAttributes()
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "The ID of this resource.",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["organization"] = schema.StringAttribute{
+		MarkdownDescription: "The slug of the organization to create the rule for. Defaults to the provider-level `organization`.",
+		Optional:            true,
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sentry Organization Data Scrubbing Rule resource. This resource manages an [advanced data scrubbing](https://docs.sentry.io/security-legal-pii/scrubbing/advanced-datascrubbing/) rule of an organization, which applies to all of its projects, leaving its other rules as they are.",
+
+		Attributes: attributes,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *OrganizationDataScrubbingRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateDataScrubbingRuleConfig(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r *OrganizationDataScrubbingRuleResource) store(organization string) relayPiiConfigStore {
+	return relayPiiConfigStore{
+		lockKey: providerdata.RelayPiiConfigLockKey(organization, ""),
+		get: func(ctx context.Context) (*sentryclient.RelayPiiConfig, error) {
+			return sentryclient.GetOrganizationRelayPiiConfig(ctx, r.client, organization)
+		},
+		update: func(ctx context.Context, config *sentryclient.RelayPiiConfig) error {
+			return sentryclient.UpdateOrganizationRelayPiiConfig(ctx, r.client, organization, config)
+		},
+	}
+}
+
+func (r *OrganizationDataScrubbingRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationDataScrubbingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
+	key, err := newDataScrubbingRuleKey()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error generating the key of the organization data scrubbing rule: %s", err))
+		return
+	}
+
+	rule := data.rule()
+	err = r.store(data.Organization.ValueString()).modify(ctx, func(config *sentryclient.RelayPiiConfig) error {
+		return config.SetRule(key, rule.relayRule(), rule.Source.ValueString())
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error creating organization data scrubbing rule", err)
+		return
+	}
+
+	rule.RuleId = types.StringValue(key)
+	if err := data.Fill(data.Organization.ValueString(), rule); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling organization data scrubbing rule: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationDataScrubbingRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrganizationDataScrubbingRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	config, err := r.store(data.Organization.ValueString()).get(ctx)
+	if err != nil {
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "organization data scrubbing rule", err)
+		return
+	}
+
+	rule := data.rule()
+	key, ok := rule.find(ctx, config)
+	if !ok {
+		removeNotFound(ctx, &resp.Diagnostics, &resp.State, "organization data scrubbing rule")
+		return
+	}
+	relayRule, ok := config.Rule(key)
+	if !ok {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading organization data scrubbing rule: the rule %q is not a rule created by the Sentry UI", key))
+		return
+	}
+	source, _ := config.Source(key)
+	rule.Fill(key, *relayRule, source)
+
+	if err := data.Fill(data.Organization.ValueString(), rule); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling organization data scrubbing rule: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationDataScrubbingRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state OrganizationDataScrubbingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
+	rule := data.rule()
+	err := r.store(data.Organization.ValueString()).modify(ctx, func(config *sentryclient.RelayPiiConfig) error {
+		// The rules may have been renumbered since they were last read
+		if key, ok := state.rule().find(ctx, config); ok {
+			rule.RuleId = types.StringValue(key)
+		}
+		return config.SetRule(rule.RuleId.ValueString(), rule.relayRule(), rule.Source.ValueString())
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error updating organization data scrubbing rule", err)
+		return
+	}
+
+	if err := data.Fill(data.Organization.ValueString(), rule); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling organization data scrubbing rule: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationDataScrubbingRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrganizationDataScrubbingRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	err := r.store(data.Organization.ValueString()).modify(ctx, func(config *sentryclient.RelayPiiConfig) error {
+		// The rules may have been renumbered since they were last read
+		if key, ok := data.rule().find(ctx, config); ok {
+			config.RemoveRule(key)
+		}
+		return nil
+	})
+	if err != nil {
+		addDeleteError(&resp.Diagnostics, "organization data scrubbing rule", err)
+		return
+	}
+}
+
+func (r *OrganizationDataScrubbingRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organization, ruleId, err := splitTwoPartID(req.ID, "organization", "rule-id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Error parsing ID: %s", err.Error()))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("organization"), organization,
+	)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("rule_id"), ruleId,
+	)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("id"), req.ID,
+	)...)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/jianyuan/terraform-provider-sentry/internal/acctest"
+)
+
+func TestAccOrganizationDataScrubbingRuleResource(t *testing.T) {
+	rn := "sentry_organization_data_scrubbing_rule.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationDataScrubbingRuleConfig("hash", "ip", "$user.ip_address"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("organization"), knownvalue.StringExact(acctest.TestOrganization)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("rule_id"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("method"), knownvalue.StringExact("hash")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("data_type"), knownvalue.StringExact("ip")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("source"), knownvalue.StringExact("$user.ip_address")),
+				},
+			},
+			{
+				Config: testAccOrganizationDataScrubbingRuleConfig("remove", "email", "$string"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("method"), knownvalue.StringExact("remove")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("data_type"), knownvalue.StringExact("email")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("source"), knownvalue.StringExact("$string")),
+				},
+			},
+			{
+				ResourceName:      rn,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccOrganizationDataScrubbingRuleConfig(method, dataType, source string) string {
+	return testAccOrganizationDataSourceConfig + fmt.Sprintf(`
+resource "sentry_organization_data_scrubbing_rule" "test" {
+	organization = data.sentry_organization.test.id
+	method       = "%[1]s"
+	data_type    = "%[2]s"
+	source       = "%[3]s"
+}
+`, method, dataType, source)
+}
@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+)
+
+var _ resource.Resource = &ProjectDataScrubbingRuleResource{}
+var _ resource.ResourceWithConfigure = &ProjectDataScrubbingRuleResource{}
+var _ resource.ResourceWithImportState = &ProjectDataScrubbingRuleResource{}
+var _ resource.ResourceWithValidateConfig = &ProjectDataScrubbingRuleResource{}
+
+func NewProjectDataScrubbingRuleResource() resource.Resource {
+	return &ProjectDataScrubbingRuleResource{}
+}
+
+type ProjectDataScrubbingRuleResource struct {
+	baseResource
+}
+
+type ProjectDataScrubbingRuleResourceModel struct {
+	Id           types.String   `tfsdk:"id"`
+	Organization types.String   `tfsdk:"organization"`
+	Project      types.String   `tfsdk:"project"`
+	RuleId       types.String   `tfsdk:"rule_id"`
+	Method       types.String   `tfsdk:"method"`
+	Replacement  types.String   `tfsdk:"replacement"`
+	DataType     types.String   `tfsdk:"data_type"`
+	Pattern      types.String   `tfsdk:"pattern"`
+	Source       types.String   `tfsdk:"source"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (m *ProjectDataScrubbingRuleResourceModel) rule() dataScrubbingRule {
+	return dataScrubbingRule{
+		RuleId:      m.RuleId,
+		Method:      m.Method,
+		Replacement: m.Replacement,
+		DataType:    m.DataType,
+		Pattern:     m.Pattern,
+		Source:      m.Source,
+	}
+}
+
+func (m *ProjectDataScrubbingRuleResourceModel) Fill(organization string, project string, rule dataScrubbingRule) error {
+	m.Id = types.StringValue(buildThreePartID(organization, project, rule.RuleId.ValueString()))
+	m.Organization = types.StringValue(organization)
+	m.Project = types.StringValue(project)
+	m.RuleId = rule.RuleId
+	m.Method = rule.Method
+	m.Replacement = rule.Replacement
+	m.DataType = rule.DataType
+	m.Pattern = rule.Pattern
+	m.Source = rule.Source
+
+	return nil
+}
+
+func (r *ProjectDataScrubbingRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_data_scrubbing_rule"
+}
+
+func (r *ProjectDataScrubbingRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := dataScrubbingRuleAttributes()
+	attributes["id"] = schema.StringAttribute{
+		MarkdownDescription: "The ID of this resource.",
+		Computed:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+	attributes["organization"] = schema.StringAttribute{
+		MarkdownDescription: "The slug of the organization the project belongs to. Defaults to the provider-level `organization`.",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["project"] = schema.StringAttribute{
+		MarkdownDescription: "The slug of the project to create the rule for.",
+		Required:            true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sentry Project Data Scrubbing Rule resource. This resource manages an [advanced data scrubbing](https://docs.sentry.io/security-legal-pii/scrubbing/advanced-datascrubbing/) rule of a project, leaving its other rules as they are. Do not use it together with the `relay_pii_config` attribute of the `sentry_project` resource.",
+
+		Attributes: attributes,
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *ProjectDataScrubbingRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateDataScrubbingRuleConfig(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r *ProjectDataScrubbingRuleResource) store(organization string, project string) relayPiiConfigStore {
+	return relayPiiConfigStore{
+		lockKey: providerdata.RelayPiiConfigLockKey(organization, project),
+		get: func(ctx context.Context) (*sentryclient.RelayPiiConfig, error) {
+			return sentryclient.GetProjectRelayPiiConfig(ctx, r.client, organization, project)
+		},
+		update: func(ctx context.Context, config *sentryclient.RelayPiiConfig) error {
+			return sentryclient.UpdateProjectRelayPiiConfig(ctx, r.client, organization, project, config)
+		},
+	}
+}
+
+func (r *ProjectDataScrubbingRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProjectDataScrubbingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
+	key, err := newDataScrubbingRuleKey()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error generating the key of the project data scrubbing rule: %s", err))
+		return
+	}
+
+	rule := data.rule()
+	err = r.store(data.Organization.ValueString(), data.Project.ValueString()).modify(ctx, func(config *sentryclient.RelayPiiConfig) error {
+		return config.SetRule(key, rule.relayRule(), rule.Source.ValueString())
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error creating project data scrubbing rule", err)
+		return
+	}
+
+	rule.RuleId = types.StringValue(key)
+	if err := data.Fill(data.Organization.ValueString(), data.Project.ValueString(), rule); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling project data scrubbing rule: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectDataScrubbingRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProjectDataScrubbingRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	config, err := r.store(data.Organization.ValueString(), data.Project.ValueString()).get(ctx)
+	if err != nil {
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "project data scrubbing rule", err)
+		return
+	}
+
+	rule := data.rule()
+	key, ok := rule.find(ctx, config)
+	if !ok {
+		removeNotFound(ctx, &resp.Diagnostics, &resp.State, "project data scrubbing rule")
+		return
+	}
+	relayRule, ok := config.Rule(key)
+	if !ok {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading project data scrubbing rule: the rule %q is not a rule created by the Sentry UI", key))
+		return
+	}
+	source, _ := config.Source(key)
+	rule.Fill(key, *relayRule, source)
+
+	if err := data.Fill(data.Organization.ValueString(), data.Project.ValueString(), rule); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling project data scrubbing rule: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectDataScrubbingRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ProjectDataScrubbingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
+	rule := data.rule()
+	err := r.store(data.Organization.ValueString(), data.Project.ValueString()).modify(ctx, func(config *sentryclient.RelayPiiConfig) error {
+		// The rules may have been renumbered since they were last read
+		if key, ok := state.rule().find(ctx, config); ok {
+			rule.RuleId = types.StringValue(key)
+		}
+		return config.SetRule(rule.RuleId.ValueString(), rule.relayRule(), rule.Source.ValueString())
+	})
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error updating project data scrubbing rule", err)
+		return
+	}
+
+	if err := data.Fill(data.Organization.ValueString(), data.Project.ValueString(), rule); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling project data scrubbing rule: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectDataScrubbingRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ProjectDataScrubbingRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	err := r.store(data.Organization.ValueString(), data.Project.ValueString()).modify(ctx, func(config *sentryclient.RelayPiiConfig) error {
+		// The rules may have been renumbered since they were last read
+		if key, ok := data.rule().find(ctx, config); ok {
+			config.RemoveRule(key)
+		}
+		return nil
+	})
+	if err != nil {
+		addDeleteError(&resp.Diagnostics, "project data scrubbing rule", err)
+		return
+	}
+}
+
+func (r *ProjectDataScrubbingRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organization, project, ruleId, err := splitThreePartID(req.ID, "organization", "project-slug", "rule-id")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Error parsing ID: %s", err.Error()))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("organization"), organization,
+	)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("project"), project,
+	)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("rule_id"), ruleId,
+	)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("id"), req.ID,
+	)...)
+}
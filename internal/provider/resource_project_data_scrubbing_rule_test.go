@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/jianyuan/terraform-provider-sentry/internal/acctest"
+)
+
+func TestAccProjectDataScrubbingRuleResource(t *testing.T) {
+	rn := "sentry_project_data_scrubbing_rule.test"
+	teamName := acctest.RandomWithPrefix("tf-team")
+	projectName := acctest.RandomWithPrefix("tf-project")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectDataScrubbingRuleConfig(teamName, projectName, `
+	method    = "mask"
+	data_type = "creditcard"
+	source    = "$string"
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("organization"), knownvalue.StringExact(acctest.TestOrganization)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("project"), knownvalue.StringExact(projectName)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("rule_id"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("method"), knownvalue.StringExact("mask")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("replacement"), knownvalue.Null()),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("data_type"), knownvalue.StringExact("creditcard")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("pattern"), knownvalue.Null()),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("source"), knownvalue.StringExact("$string")),
+					statecheck.ExpectKnownValue("sentry_project_data_scrubbing_rule.other", tfjsonpath.New("data_type"), knownvalue.StringExact("password")),
+				},
+			},
+			{
+				Config: testAccProjectDataScrubbingRuleConfig(teamName, projectName, `
+	method      = "replace"
+	replacement = "[redacted]"
+	data_type   = "pattern"
+	pattern     = "[a-f0-9]{32}"
+	source      = "$http.headers.x-custom-token"
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("method"), knownvalue.StringExact("replace")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("replacement"), knownvalue.StringExact("[redacted]")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("data_type"), knownvalue.StringExact("pattern")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("pattern"), knownvalue.StringExact("[a-f0-9]{32}")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("source"), knownvalue.StringExact("$http.headers.x-custom-token")),
+					statecheck.ExpectKnownValue("sentry_project_data_scrubbing_rule.other", tfjsonpath.New("data_type"), knownvalue.StringExact("password")),
+				},
+			},
+			{
+				ResourceName:      rn,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccProjectDataScrubbingRuleConfig(teamName, projectName, `
+	method    = "mask"
+	data_type = "pattern"
+	source    = "$string"
+				`),
+				ExpectError: regexp.MustCompile("Missing pattern"),
+			},
+		},
+	})
+}
+
+func testAccProjectDataScrubbingRuleConfig(teamName, projectName, rule string) string {
+	return testAccOrganizationDataSourceConfig + fmt.Sprintf(`
+resource "sentry_team" "test" {
+	organization = data.sentry_organization.test.id
+	name         = "%[1]s"
+	slug         = "%[1]s"
+}
+
+resource "sentry_project" "test" {
+	organization = sentry_team.test.organization
+	teams        = [sentry_team.test.id]
+	name         = "%[2]s"
+	platform     = "go"
+}
+
+resource "sentry_project_data_scrubbing_rule" "test" {
+	organization = sentry_project.test.organization
+	project      = sentry_project.test.id
+%[3]s
+}
+
+resource "sentry_project_data_scrubbing_rule" "other" {
+	organization = sentry_project.test.organization
+	project      = sentry_project.test.id
+	method       = "remove"
+	data_type    = "password"
+	source       = "$string"
+}
+`, teamName, projectName, rule)
+}
@@ -3,8 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 var _ resource.Resource = &ProjectInboundDataFilterResource{}
@@ -31,12 +32,13 @@ type ProjectInboundDataFilterResource struct {
 }
 
 type ProjectInboundDataFilterResourceModel struct {
-	Id           types.String `tfsdk:"id"`
-	Organization types.String `tfsdk:"organization"`
-	Project      types.String `tfsdk:"project"`
-	FilterId     types.String `tfsdk:"filter_id"`
-	Active       types.Bool   `tfsdk:"active"`
-	Subfilters   types.Set    `tfsdk:"subfilters"`
+	Id           types.String   `tfsdk:"id"`
+	Organization types.String   `tfsdk:"organization"`
+	Project      types.String   `tfsdk:"project"`
+	FilterId     types.String   `tfsdk:"filter_id"`
+	Active       types.Bool     `tfsdk:"active"`
+	Subfilters   types.Set      `tfsdk:"subfilters"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (m *ProjectInboundDataFilterResourceModel) Fill(organization string, project string, filterId string, filter sentry.ProjectInboundDataFilter) error {
@@ -76,16 +78,23 @@ func (r *ProjectInboundDataFilterResource) Schema(ctx context.Context, req resou
 				},
 			},
 			"organization": schema.StringAttribute{
-				Description: "The slug of the organization the project belongs to.",
-				Required:    true,
+				Description: "The slug of the organization the project belongs to. Defaults to the provider-level `organization`.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"project": schema.StringAttribute{
 				Description: "The slug of the project to create the filter for.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"filter_id": schema.StringAttribute{
 				Description: "The type of filter toggle to update. See the [Sentry documentation](https://docs.sentry.io/api/projects/update-an-inbound-data-filter/) for a list of available filters.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"active": schema.BoolAttribute{
 				Description: "Toggle the browser-extensions, localhost, filtered-transaction, or web-crawlers filter on or off.",
@@ -107,6 +116,9 @@ func (r *ProjectInboundDataFilterResource) Schema(ctx context.Context, req resou
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -119,6 +131,9 @@ func (r *ProjectInboundDataFilterResource) Create(ctx context.Context, req resou
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
 	subfilters := []string{}
 	if !data.Subfilters.IsNull() {
 		resp.Diagnostics.Append(data.Subfilters.ElementsAs(ctx, &subfilters, false)...)
@@ -135,7 +150,7 @@ func (r *ProjectInboundDataFilterResource) Create(ctx context.Context, req resou
 		},
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating project inbound data filter: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error creating project inbound data filter", err)
 		return
 	}
 
@@ -153,14 +168,16 @@ func (r *ProjectInboundDataFilterResource) Read(ctx context.Context, req resourc
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
 	filters, _, err := r.client.ProjectInboundDataFilters.List(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading project inbound data filters: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "project inbound data filter", err)
 		return
 	}
 
@@ -174,8 +191,7 @@ func (r *ProjectInboundDataFilterResource) Read(ctx context.Context, req resourc
 	}
 
 	if foundFilter == nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading project inbound data filters: %s", "Filter not found"))
-		resp.State.RemoveResource(ctx)
+		removeNotFound(ctx, &resp.Diagnostics, &resp.State, "project inbound data filter")
 		return
 	}
 
@@ -196,6 +212,9 @@ func (r *ProjectInboundDataFilterResource) Update(ctx context.Context, req resou
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, plan.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
 	subfilters := []string{}
 	if !plan.Subfilters.IsNull() {
 		resp.Diagnostics.Append(plan.Subfilters.ElementsAs(ctx, &subfilters, false)...)
@@ -212,7 +231,7 @@ func (r *ProjectInboundDataFilterResource) Update(ctx context.Context, req resou
 		},
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating project inbound data filter: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, nil, "Error updating project inbound data filter", err)
 		return
 	}
 
@@ -230,7 +249,10 @@ func (r *ProjectInboundDataFilterResource) Delete(ctx context.Context, req resou
 		return
 	}
 
-	apiResp, err := r.client.ProjectInboundDataFilters.Update(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	_, err := r.client.ProjectInboundDataFilters.Update(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
@@ -239,12 +261,8 @@ func (r *ProjectInboundDataFilterResource) Delete(ctx context.Context, req resou
 			Active: sentry.Bool(false),
 		},
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		return
-	}
-
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting project inbound data filter: %s", err.Error()))
+		addDeleteError(&resp.Diagnostics, "project inbound data filter", err)
 		return
 	}
 }
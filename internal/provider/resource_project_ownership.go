@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryownership"
+)
+
+var _ resource.Resource = &ProjectOwnershipResource{}
+var _ resource.ResourceWithConfigure = &ProjectOwnershipResource{}
+var _ resource.ResourceWithImportState = &ProjectOwnershipResource{}
+var _ resource.ResourceWithModifyPlan = &ProjectOwnershipResource{}
+
+func NewProjectOwnershipResource() resource.Resource {
+	return &ProjectOwnershipResource{}
+}
+
+type ProjectOwnershipResource struct {
+	baseResource
+}
+
+type ProjectOwnershipResourceModel struct {
+	Id                 types.String   `tfsdk:"id"`
+	Organization       types.String   `tfsdk:"organization"`
+	Project            types.String   `tfsdk:"project"`
+	Raw                types.String   `tfsdk:"raw"`
+	FallThrough        types.Bool     `tfsdk:"fallthrough"`
+	AutoAssignment     types.String   `tfsdk:"auto_assignment"`
+	CodeownersAutoSync types.Bool     `tfsdk:"codeowners_auto_sync"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (data *ProjectOwnershipResourceModel) Fill(organization string, project string, ownership sentry.ProjectOwnership) error {
+	data.Id = types.StringValue(buildTwoPartID(organization, project))
+	data.Organization = types.StringValue(organization)
+	data.Project = types.StringValue(project)
+	data.Raw = types.StringValue(ownership.Raw)
+	data.FallThrough = types.BoolValue(ownership.FallThrough)
+	data.AutoAssignment = types.StringValue(ownership.AutoAssignment)
+	data.CodeownersAutoSync = types.BoolPointerValue(ownership.CodeownersAutoSync)
+
+	return nil
+}
+
+// params returns the parameters that update the settings known in the plan,
+// leaving the others as they are in Sentry.
+func (data *ProjectOwnershipResourceModel) params() *sentryclient.UpdateProjectOwnershipParams {
+	params := &sentryclient.UpdateProjectOwnershipParams{}
+	if !data.Raw.IsUnknown() && !data.Raw.IsNull() {
+		params.Raw = data.Raw.ValueStringPointer()
+	}
+	if !data.FallThrough.IsUnknown() && !data.FallThrough.IsNull() {
+		params.FallThrough = data.FallThrough.ValueBoolPointer()
+	}
+	if !data.AutoAssignment.IsUnknown() && !data.AutoAssignment.IsNull() {
+		params.AutoAssignment = data.AutoAssignment.ValueStringPointer()
+	}
+	if !data.CodeownersAutoSync.IsUnknown() && !data.CodeownersAutoSync.IsNull() {
+		params.CodeownersAutoSync = data.CodeownersAutoSync.ValueBoolPointer()
+	}
+	return params
+}
+
+// projectOwnershipAutoAssignments are the auto-assignment settings of a
+// project.
+var projectOwnershipAutoAssignments = []string{
+	"Auto Assign to Suspect Commits",
+	"Auto Assign to Issue Owner",
+	"Turn off Auto-Assignment",
+}
+
+// defaultProjectOwnership are the settings of a project without ownership
+// rules, which are restored when the resource is destroyed.
+var defaultProjectOwnership = sentryclient.UpdateProjectOwnershipParams{
+	Raw:                sentry.String(""),
+	FallThrough:        sentry.Bool(true),
+	AutoAssignment:     sentry.String("Auto Assign to Issue Owner"),
+	CodeownersAutoSync: sentry.Bool(true),
+}
+
+// projectOwnershipFieldPaths maps the fields of ownership requests to
+// attributes.
+var projectOwnershipFieldPaths = map[string]string{
+	"raw":                "raw",
+	"fallthrough":        "fallthrough",
+	"autoAssignment":     "auto_assignment",
+	"codeownersAutoSync": "codeowners_auto_sync",
+}
+
+func (r *ProjectOwnershipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_ownership"
+}
+
+func (r *ProjectOwnershipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sentry Project Ownership resource. This resource is used to manage the ownership rules and the auto-assignment of issues of a project. Destroying it clears the rules and restores the default settings.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of this resource.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "The slug of the organization the project belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "The slug of the project.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"raw": schema.StringAttribute{
+				MarkdownDescription: "The ownership rules, one per line, such as `path:src/billing/* #billing jane@example.com`. Each rule is a `path:`, `url:`, `module:`, `codeowners:` or `tags.<name>:` matcher followed by the owners, which are teams such as `#billing` or the emails of members. Owners that are not teams or members of the organization are reported as warnings when planning.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					ownershipRulesValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fallthrough": schema.BoolAttribute{
+				MarkdownDescription: "Whether all users are notified of issues that match no ownership rule.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"auto_assignment": schema.StringAttribute{
+				MarkdownDescription: "How issues are automatically assigned. One of `Auto Assign to Suspect Commits`, `Auto Assign to Issue Owner` or `Turn off Auto-Assignment`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(projectOwnershipAutoAssignments...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"codeowners_auto_sync": schema.BoolAttribute{
+				MarkdownDescription: "Whether the CODEOWNERS files of the code mappings of the project are synced on new commits.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+// ModifyPlan warns about the owners in the rules that are neither teams nor
+// members of the organization, which Sentry rejects when applying.
+func (r *ProjectOwnershipResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.baseResource.ModifyPlan(ctx, req, resp)
+	if req.Plan.Raw.IsNull() || r.client == nil || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var organization, raw types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("organization"), &organization)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("raw"), &raw)...)
+	if resp.Diagnostics.HasError() || organization.IsUnknown() || raw.IsUnknown() || raw.IsNull() {
+		return
+	}
+
+	// Syntax errors are reported by the validator of the attribute
+	rules, err := sentryownership.Parse(raw.ValueString())
+	if err != nil {
+		return
+	}
+
+	var owners []string
+	for _, rule := range rules {
+		for _, owner := range rule.Owners {
+			if !slices.Contains(owners, owner) {
+				owners = append(owners, owner)
+			}
+		}
+	}
+	if len(owners) == 0 {
+		return
+	}
+
+	teams, err := r.cache.Teams(ctx, r.providerData.Client, organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("raw"), "Unable to check owners", fmt.Sprintf("Error reading teams: %s", err.Error()))
+		return
+	}
+	members, err := r.cache.Members(ctx, r.providerData.Client, organization.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("raw"), "Unable to check owners", fmt.Sprintf("Error reading members: %s", err.Error()))
+		return
+	}
+
+	for _, owner := range unknownOwners(owners, teams, members) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("raw"),
+			"Unknown owner",
+			fmt.Sprintf("The owner %q is not a team or member of the organization %q. Sentry rejects rules with unknown owners.", owner, organization.ValueString()),
+		)
+	}
+}
+
+// unknownOwners returns the owners that are neither the slug of one of teams,
+// such as `#billing`, nor the email of one of members.
+func unknownOwners(owners []string, teams []*sentry.Team, members []*sentry.OrganizationMember) []string {
+	var unknown []string
+	for _, owner := range owners {
+		var found bool
+		if sentryownership.IsTeam(owner) {
+			found = slices.ContainsFunc(teams, func(team *sentry.Team) bool {
+				return team.Slug != nil && *team.Slug == strings.TrimPrefix(owner, "#")
+			})
+		} else {
+			found = slices.ContainsFunc(members, func(member *sentry.OrganizationMember) bool {
+				return strings.EqualFold(member.Email, owner) || strings.EqualFold(member.User.Email, owner)
+			})
+		}
+		if !found {
+			unknown = append(unknown, owner)
+		}
+	}
+	return unknown
+}
+
+func (r *ProjectOwnershipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProjectOwnershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
+	ownership, _, err := sentryclient.UpdateProjectOwnership(
+		ctx,
+		r.client,
+		data.Organization.ValueString(),
+		data.Project.ValueString(),
+		data.params(),
+	)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan, projectOwnershipFieldPaths, "Error updating project ownership", err)
+		return
+	}
+
+	if err := data.Fill(data.Organization.ValueString(), data.Project.ValueString(), *ownership); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling project ownership: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectOwnershipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProjectOwnershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	ownership, _, err := r.client.ProjectOwnerships.Get(
+		ctx,
+		data.Organization.ValueString(),
+		data.Project.ValueString(),
+	)
+	if err != nil {
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "project ownership", err)
+		return
+	}
+
+	if err := data.Fill(data.Organization.ValueString(), data.Project.ValueString(), *ownership); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling project ownership: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectOwnershipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ProjectOwnershipResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
+	ownership, _, err := sentryclient.UpdateProjectOwnership(
+		ctx,
+		r.client,
+		data.Organization.ValueString(),
+		data.Project.ValueString(),
+		data.params(),
+	)
+	if err != nil {
+		addClientError(ctx, &resp.Diagnostics, req.Plan, projectOwnershipFieldPaths, "Error updating project ownership", err)
+		return
+	}
+
+	if err := data.Fill(data.Organization.ValueString(), data.Project.ValueString(), *ownership); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error filling project ownership: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ProjectOwnershipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ProjectOwnershipResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	params := defaultProjectOwnership
+	_, _, err := sentryclient.UpdateProjectOwnership(
+		ctx,
+		r.client,
+		data.Organization.ValueString(),
+		data.Project.ValueString(),
+		&params,
+	)
+	if err != nil {
+		addDeleteError(&resp.Diagnostics, "project ownership", err)
+		return
+	}
+}
+
+func (r *ProjectOwnershipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organization, project, err := splitTwoPartID(req.ID, "organization", "project-slug")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Error parsing ID: %s", err.Error()))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("organization"), organization,
+	)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("project"), project,
+	)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(
+		ctx, path.Root("id"), req.ID,
+	)...)
+}
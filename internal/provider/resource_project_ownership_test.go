@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/acctest"
+)
+
+func TestAccProjectOwnershipResource(t *testing.T) {
+	rn := "sentry_project_ownership.test"
+	teamName := acctest.RandomWithPrefix("tf-team")
+	projectName := acctest.RandomWithPrefix("tf-project")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectOwnershipResourceConfig(teamName, projectName, `
+	raw = "path:src/billing/* #${sentry_team.test.slug}"
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("id"), knownvalue.StringExact(buildTwoPartID(acctest.TestOrganization, projectName))),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("organization"), knownvalue.StringExact(acctest.TestOrganization)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("project"), knownvalue.StringExact(projectName)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("raw"), knownvalue.StringExact("path:src/billing/* #"+teamName)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("fallthrough"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("auto_assignment"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("codeowners_auto_sync"), knownvalue.NotNull()),
+				},
+			},
+			{
+				Config: testAccProjectOwnershipResourceConfig(teamName, projectName, `
+	raw                  = "url:*/checkout/* #${sentry_team.test.slug}\ntags.sku_class:enterprise #${sentry_team.test.slug}"
+	fallthrough          = false
+	auto_assignment      = "Turn off Auto-Assignment"
+	codeowners_auto_sync = false
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("raw"), knownvalue.StringExact("url:*/checkout/* #"+teamName+"\ntags.sku_class:enterprise #"+teamName)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("fallthrough"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("auto_assignment"), knownvalue.StringExact("Turn off Auto-Assignment")),
+					statecheck.ExpectKnownValue(rn, tfjsonpath.New("codeowners_auto_sync"), knownvalue.Bool(false)),
+				},
+			},
+			{
+				ResourceName:      rn,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccProjectOwnershipResourceConfig(teamName, projectName, `
+	raw = "src/billing/* #${sentry_team.test.slug}"
+				`),
+				ExpectError: regexp.MustCompile("Invalid ownership rules"),
+			},
+			{
+				// Unknown owners are warned about when planning, and rejected
+				// by Sentry when applying
+				Config: testAccProjectOwnershipResourceConfig(teamName, projectName, `
+	raw = "path:src/billing/* #no-such-team nobody@example.com"
+				`),
+				ExpectError: regexp.MustCompile("Invalid rule owners"),
+			},
+		},
+	})
+}
+
+func testAccProjectOwnershipResourceConfig(teamName, projectName, extras string) string {
+	return testAccOrganizationDataSourceConfig + fmt.Sprintf(`
+resource "sentry_team" "test" {
+	organization = data.sentry_organization.test.id
+	name         = "%[1]s"
+	slug         = "%[1]s"
+}
+
+resource "sentry_project" "test" {
+	organization = sentry_team.test.organization
+	teams        = [sentry_team.test.id]
+	name         = "%[2]s"
+	platform     = "go"
+}
+
+resource "sentry_project_ownership" "test" {
+	organization = sentry_project.test.organization
+	project      = sentry_project.test.id
+	%[3]s
+}
+`, teamName, projectName, extras)
+}
+
+func TestUnknownOwners(t *testing.T) {
+	teams := []*sentry.Team{
+		{Slug: sentry.String("billing")},
+	}
+	members := []*sentry.OrganizationMember{
+		{Email: "jane@example.com"},
+		{Email: "invited@example.com", User: sentry.User{Email: "john@example.com"}},
+	}
+
+	got := unknownOwners([]string{"#billing", "#no-such-team", "Jane@example.com", "john@example.com", "nobody@example.com", "#jane@example.com"}, teams, members)
+	want := []string{"#no-such-team", "nobody@example.com", "#jane@example.com"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -3,8 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 var _ resource.Resource = &ProjectSpikeProtectionResource{}
@@ -27,10 +28,11 @@ type ProjectSpikeProtectionResource struct {
 }
 
 type ProjectSpikeProtectionResourceModel struct {
-	Id           types.String `tfsdk:"id"`
-	Organization types.String `tfsdk:"organization"`
-	Project      types.String `tfsdk:"project"`
-	Enabled      types.Bool   `tfsdk:"enabled"`
+	Id           types.String   `tfsdk:"id"`
+	Organization types.String   `tfsdk:"organization"`
+	Project      types.String   `tfsdk:"project"`
+	Enabled      types.Bool     `tfsdk:"enabled"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (data *ProjectSpikeProtectionResourceModel) Fill(organization string, project sentry.Project) error {
@@ -44,6 +46,12 @@ func (data *ProjectSpikeProtectionResourceModel) Fill(organization string, proje
 	return nil
 }
 
+// projectSpikeProtectionFieldPaths maps the fields of spike protection requests
+// to attributes.
+var projectSpikeProtectionFieldPaths = map[string]string{
+	"projects": "project",
+}
+
 func (r *ProjectSpikeProtectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_project_spike_protection"
 }
@@ -61,18 +69,25 @@ func (r *ProjectSpikeProtectionResource) Schema(ctx context.Context, req resourc
 				},
 			},
 			"organization": schema.StringAttribute{
-				MarkdownDescription: "The slug of the organization the project belongs to.",
-				Required:            true,
+				MarkdownDescription: "The slug of the organization the project belongs to. Defaults to the provider-level `organization`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"project": schema.StringAttribute{
 				MarkdownDescription: "The slug of the project to enable or disable spike protection for.",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"enabled": schema.BoolAttribute{
 				MarkdownDescription: "Toggle the browser-extensions, localhost, filtered-transaction, or web-crawlers filter on or off.",
 				Required:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -85,6 +100,9 @@ func (r *ProjectSpikeProtectionResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
 	if data.Enabled.ValueBool() {
 		_, err := r.client.SpikeProtections.Enable(
 			ctx,
@@ -94,7 +112,7 @@ func (r *ProjectSpikeProtectionResource) Create(ctx context.Context, req resourc
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error enabling spike protection: %s", err.Error()))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, projectSpikeProtectionFieldPaths, "Error enabling spike protection", err)
 			return
 		}
 	} else {
@@ -106,7 +124,7 @@ func (r *ProjectSpikeProtectionResource) Create(ctx context.Context, req resourc
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error disabling spike protection: %s", err.Error()))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, projectSpikeProtectionFieldPaths, "Error disabling spike protection", err)
 			return
 		}
 	}
@@ -125,18 +143,16 @@ func (r *ProjectSpikeProtectionResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	project, apiResp, err := r.client.Projects.Get(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	project, _, err := r.client.Projects.Get(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Project not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading project: %s", err.Error()))
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "project spike protection", err)
 		return
 	}
 
@@ -157,6 +173,9 @@ func (r *ProjectSpikeProtectionResource) Update(ctx context.Context, req resourc
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
 	if data.Enabled.ValueBool() {
 		_, err := r.client.SpikeProtections.Enable(
 			ctx,
@@ -166,7 +185,7 @@ func (r *ProjectSpikeProtectionResource) Update(ctx context.Context, req resourc
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error enabling spike protection: %s", err.Error()))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, projectSpikeProtectionFieldPaths, "Error enabling spike protection", err)
 			return
 		}
 	} else {
@@ -178,7 +197,7 @@ func (r *ProjectSpikeProtectionResource) Update(ctx context.Context, req resourc
 			},
 		)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error disabling spike protection: %s", err.Error()))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, projectSpikeProtectionFieldPaths, "Error disabling spike protection", err)
 			return
 		}
 	}
@@ -195,19 +214,18 @@ func (r *ProjectSpikeProtectionResource) Delete(ctx context.Context, req resourc
 		return
 	}
 
-	apiResp, err := r.client.SpikeProtections.Disable(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	_, err := r.client.SpikeProtections.Disable(
 		ctx,
 		data.Organization.ValueString(),
 		&sentry.SpikeProtectionParams{
 			Projects: []string{data.Project.ValueString()},
 		},
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		return
-	}
-
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error disabling spike protection: %s", err.Error()))
+		addDeleteError(&resp.Diagnostics, "project spike protection", err)
 		return
 	}
 }
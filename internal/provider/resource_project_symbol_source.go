@@ -3,8 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 var _ resource.Resource = &ProjectSymbolSourcesResource{}
@@ -49,6 +50,14 @@ type ProjectSymbolSourcesResourceModel struct {
 	Prefix               types.String                             `tfsdk:"prefix"`
 	ClientEmail          types.String                             `tfsdk:"client_email"`
 	PrivateKey           types.String                             `tfsdk:"private_key"`
+	Timeouts             timeouts.Value                           `tfsdk:"timeouts"`
+}
+
+// projectSymbolSourceFieldPaths maps the fields of symbol source requests to
+// attributes.
+var projectSymbolSourceFieldPaths = map[string]string{
+	"appconnectIssuer":     "app_connect_issuer",
+	"appconnectPrivateKey": "app_connect_private_key",
 }
 
 func (data *ProjectSymbolSourcesResourceModel) Fill(source sentry.ProjectSymbolSource) error {
@@ -110,12 +119,16 @@ func (r *ProjectSymbolSourcesResource) Schema(ctx context.Context, req resource.
 				},
 			},
 			"organization": schema.StringAttribute{
-				Description: "The slug of the organization the project belongs to.",
-				Required:    true,
+				Description: "The slug of the organization the project belongs to. Defaults to the provider-level `organization`.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"project": schema.StringAttribute{
 				Description: "The slug of the project to create the filter for.",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"type": schema.StringAttribute{
 				Description: "The type of symbol source. One of `appStoreConnect` (App Store Connect), `http` (SymbolServer (HTTP)), `gcs` (Google Cloud Storage), `s3` (Amazon S3).",
@@ -233,6 +246,9 @@ func (r *ProjectSymbolSourcesResource) Schema(ctx context.Context, req resource.
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -245,6 +261,9 @@ func (r *ProjectSymbolSourcesResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
 	params := &sentry.CreateProjectSymbolSourceParams{
 		Type:                 data.Type.ValueStringPointer(),
 		Name:                 data.Name.ValueStringPointer(),
@@ -276,7 +295,7 @@ func (r *ProjectSymbolSourcesResource) Create(ctx context.Context, req resource.
 		params,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error creating project symbol source: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, projectSymbolSourceFieldPaths, "Error creating project symbol source", err)
 		return
 	}
 
@@ -297,7 +316,10 @@ func (r *ProjectSymbolSourcesResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	sources, apiResp, err := r.client.ProjectSymbolSources.List(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
+	sources, _, err := r.client.ProjectSymbolSources.List(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
@@ -305,19 +327,13 @@ func (r *ProjectSymbolSourcesResource) Read(ctx context.Context, req resource.Re
 			ID: data.Id.ValueStringPointer(),
 		},
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Project not found: %s", err.Error()))
-		resp.State.RemoveResource(ctx)
-		return
-	}
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error reading project: %s", err.Error()))
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "project symbol source", err)
 		return
 	}
 
 	if len(sources) != 1 {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Symbol source not found: %s", data.Id.ValueString()))
-		resp.State.RemoveResource(ctx)
+		removeNotFound(ctx, &resp.Diagnostics, &resp.State, "project symbol source")
 		return
 	}
 
@@ -340,6 +356,9 @@ func (r *ProjectSymbolSourcesResource) Update(ctx context.Context, req resource.
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
 	params := &sentry.UpdateProjectSymbolSourceParams{
 		ID:                   data.Id.ValueStringPointer(),
 		Type:                 data.Type.ValueStringPointer(),
@@ -373,7 +392,7 @@ func (r *ProjectSymbolSourcesResource) Update(ctx context.Context, req resource.
 		params,
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error updating project symbol source: %s", err.Error()))
+		addClientError(ctx, &resp.Diagnostics, req.Plan, projectSymbolSourceFieldPaths, "Error updating project symbol source", err)
 		return
 	}
 
@@ -394,18 +413,17 @@ func (r *ProjectSymbolSourcesResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
-	apiResp, err := r.client.ProjectSymbolSources.Delete(
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
+	_, err := r.client.ProjectSymbolSources.Delete(
 		ctx,
 		data.Organization.ValueString(),
 		data.Project.ValueString(),
 		data.Id.ValueString(),
 	)
-	if apiResp.StatusCode == http.StatusNotFound {
-		return
-	}
-
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Error deleting project symbol source: %s", err.Error()))
+		addDeleteError(&resp.Diagnostics, "project symbol source", err)
 		return
 	}
 }
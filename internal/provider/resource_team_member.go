@@ -7,6 +7,7 @@ import (
 	"slices"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 var _ resource.Resource = &TeamMemberResource{}
@@ -33,12 +35,18 @@ type TeamMemberResource struct {
 }
 
 type TeamMemberResourceModel struct {
-	Id            types.String `tfsdk:"id"`
-	Organization  types.String `tfsdk:"organization"`
-	MemberId      types.String `tfsdk:"member_id"`
-	Team          types.String `tfsdk:"team"`
-	Role          types.String `tfsdk:"role"`
-	EffectiveRole types.String `tfsdk:"effective_role"`
+	Id            types.String   `tfsdk:"id"`
+	Organization  types.String   `tfsdk:"organization"`
+	MemberId      types.String   `tfsdk:"member_id"`
+	Team          types.String   `tfsdk:"team"`
+	Role          types.String   `tfsdk:"role"`
+	EffectiveRole types.String   `tfsdk:"effective_role"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// teamMemberFieldPaths maps the fields of team member requests to attributes.
+var teamMemberFieldPaths = map[string]string{
+	"teamRole": "role",
 }
 
 func (data *TeamMemberResourceModel) Fill(organization string, team string, memberId string, role *string, effectiveRole string) error {
@@ -69,8 +77,9 @@ func (r *TeamMemberResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"organization": schema.StringAttribute{
-				Description: "The slug of the organization the team should be created for.",
-				Required:    true,
+				Description: "The slug of the organization the team should be created for. Defaults to the provider-level `organization`.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"member_id": schema.StringAttribute{
 				Description: "The ID of the member to add to the team.",
@@ -98,6 +107,9 @@ func (r *TeamMemberResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
@@ -148,19 +160,19 @@ func (r *TeamMemberResource) getEffectiveTeamRole(ctx context.Context, organizat
 	r.roleMu.Lock()
 	defer r.roleMu.Unlock()
 
-	org, _, err := r.client.Organizations.Get(ctx, organization)
+	roles, err := r.cache.Roles(ctx, r.providerData.Client, organization)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read organization, got error: %s", err)
+		return nil, fmt.Errorf("unable to read organization roles, got error: %w", err)
 	}
 
 	team, _, err := r.client.Teams.Get(ctx, organization, teamSlug)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read team, got error: %s", err)
+		return nil, fmt.Errorf("unable to read team, got error: %w", err)
 	}
 
 	member, _, err := r.client.OrganizationMembers.Get(ctx, organization, memberId)
 	if err != nil {
-		return nil, fmt.Errorf("unable to read organization member, got error: %s", err)
+		return nil, fmt.Errorf("unable to read organization member, got error: %w", err)
 	}
 
 	possibleOrgRoles := []string{member.OrgRole}
@@ -168,14 +180,14 @@ func (r *TeamMemberResource) getEffectiveTeamRole(ctx context.Context, organizat
 		possibleOrgRoles = append(possibleOrgRoles, sentry.StringValue(team.OrgRole))
 	}
 
-	effectiveOrgRole := getEffectiveOrgRole(possibleOrgRoles, org.OrgRoleList)
+	effectiveOrgRole := getEffectiveOrgRole(possibleOrgRoles, roles.OrgRoles)
 
-	if hasOrgRoleOverwrite(effectiveOrgRole, org.OrgRoleList, org.TeamRoleList) {
-		teamRoleIndex := slices.IndexFunc(org.TeamRoleList, func(teamRole sentry.TeamRoleListItem) bool {
+	if hasOrgRoleOverwrite(effectiveOrgRole, roles.OrgRoles, roles.TeamRoles) {
+		teamRoleIndex := slices.IndexFunc(roles.TeamRoles, func(teamRole sentry.TeamRoleListItem) bool {
 			return teamRole.ID == effectiveOrgRole.MinimumTeamRole
 		})
 		if teamRoleIndex != -1 {
-			teamRole := org.TeamRoleList[teamRoleIndex]
+			teamRole := roles.TeamRoles[teamRoleIndex]
 			return &teamRole.ID, nil
 		}
 	}
@@ -202,7 +214,7 @@ func (r *TeamMemberResource) updateRole(ctx context.Context, organization string
 		TeamRole: sentry.String(role),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to read organization member, got error: %s", err)
+		return nil, fmt.Errorf("unable to update team member role, got error: %w", err)
 	}
 
 	if !sentry.BoolValue(member.IsActive) {
@@ -222,6 +234,9 @@ func (r *TeamMemberResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Create, providerdata.DefaultCreateTimeout)
+	defer cancel()
+
 	_, _, err := r.client.TeamMembers.Create(
 		ctx,
 		data.Organization.ValueString(),
@@ -236,7 +251,7 @@ func (r *TeamMemberResource) Create(ctx context.Context, req resource.CreateRequ
 	if !data.Role.IsNull() {
 		_, err = r.updateRole(ctx, data.Organization.ValueString(), data.MemberId.ValueString(), data.Team.ValueString(), data.Role.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read team member, got error: %s", err))
+			addClientError(ctx, &resp.Diagnostics, req.Plan, teamMemberFieldPaths, "Unable to update team member role", err)
 			return
 		}
 	}
@@ -273,10 +288,12 @@ func (r *TeamMemberResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Read, providerdata.DefaultReadTimeout)
+	defer cancel()
+
 	effectiveRole, err := r.getEffectiveTeamRole(ctx, data.Organization.ValueString(), data.MemberId.ValueString(), data.Team.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
-		resp.State.RemoveResource(ctx)
+		addReadError(ctx, &resp.Diagnostics, &resp.State, "team member", err)
 		return
 	}
 
@@ -305,11 +322,14 @@ func (r *TeamMemberResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, plan.Timeouts.Update, providerdata.DefaultUpdateTimeout)
+	defer cancel()
+
 	// Update the role if it has changed
 	if !plan.Role.Equal(state.Role) {
 		_, err := r.updateRole(ctx, plan.Organization.ValueString(), plan.MemberId.ValueString(), plan.Team.ValueString(), plan.Role.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", err.Error())
+			addClientError(ctx, &resp.Diagnostics, req.Plan, teamMemberFieldPaths, "Unable to update team member role", err)
 			return
 		}
 
@@ -331,6 +351,7 @@ func (r *TeamMemberResource) Update(ctx context.Context, req resource.UpdateRequ
 			return
 		}
 	}
+	state.Timeouts = plan.Timeouts
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -345,6 +366,9 @@ func (r *TeamMemberResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	ctx, cancel := r.withTimeout(ctx, &resp.Diagnostics, data.Timeouts.Delete, providerdata.DefaultDeleteTimeout)
+	defer cancel()
+
 	_, _, err := r.client.TeamMembers.Delete(
 		ctx,
 		data.Organization.ValueString(),
@@ -352,7 +376,7 @@ func (r *TeamMemberResource) Delete(ctx context.Context, req resource.DeleteRequ
 		data.Team.ValueString(),
 	)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete team member, got error: %s", err))
+		addDeleteError(&resp.Diagnostics, "team member", err)
 		return
 	}
 }
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+)
+
+// NewOperationServer wraps the muxed provider server. It records the
+// Terraform operation of each resource and data source request in the
+// context, so that resources can attribute their API requests to it (see
+// sentryclient.WithContext), and checks the token scopes of planned changes.
+//
+// p is the framework provider served by server.
+func NewOperationServer(server tfprotov6.ProviderServer, p provider.Provider) tfprotov6.ProviderServer {
+	sentryProvider, _ := p.(*SentryProvider)
+	return &operationServer{
+		ProviderServer: server,
+		provider:       sentryProvider,
+	}
+}
+
+type operationServer struct {
+	tfprotov6.ProviderServer
+
+	provider *SentryProvider
+
+	resourceTypesOnce sync.Once
+	resourceTypes     map[string]tftypes.Type
+}
+
+func (s *operationServer) providerData() *providerdata.ProviderData {
+	if s.provider == nil {
+		return nil
+	}
+	return s.provider.data.Load()
+}
+
+func (s *operationServer) withOperation(ctx context.Context, typeName string, name string) context.Context {
+	op := sentryclient.Operation{
+		TypeName: typeName,
+		Name:     name,
+	}
+
+	if data := s.providerData(); data != nil && data.TokenInfo != nil {
+		op.TokenType = data.TokenInfo.Type
+		switch name {
+		case sentryclient.OperationCreate, sentryclient.OperationUpdate, sentryclient.OperationDelete:
+			op.MissingScopes = data.MissingScopes(typeName)
+		}
+	}
+
+	return sentryclient.ContextWithOperation(ctx, op)
+}
+
+// resourceType returns the value type of a resource, or nil if unknown.
+func (s *operationServer) resourceType(ctx context.Context, typeName string) tftypes.Type {
+	s.resourceTypesOnce.Do(func() {
+		resp, err := s.ProviderServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+		if err != nil {
+			return
+		}
+
+		s.resourceTypes = make(map[string]tftypes.Type, len(resp.ResourceSchemas))
+		for name, schema := range resp.ResourceSchemas {
+			s.resourceTypes[name] = schema.ValueType()
+		}
+	})
+	return s.resourceTypes[typeName]
+}
+
+// isNull reports whether v is missing or null.
+func isNull(v *tfprotov6.DynamicValue) bool {
+	if v == nil {
+		return true
+	}
+	null, err := v.IsNull()
+	return err == nil && null
+}
+
+// changes reports whether a plan creates or updates a resource.
+func (s *operationServer) changes(ctx context.Context, typeName string, prior *tfprotov6.DynamicValue, planned *tfprotov6.DynamicValue) bool {
+	if isNull(planned) {
+		return false
+	}
+	if isNull(prior) {
+		return true
+	}
+
+	typ := s.resourceType(ctx, typeName)
+	if typ == nil {
+		return true
+	}
+	priorValue, err := prior.Unmarshal(typ)
+	if err != nil {
+		return true
+	}
+	plannedValue, err := planned.Unmarshal(typ)
+	if err != nil {
+		return true
+	}
+	return !priorValue.Equal(plannedValue)
+}
+
+func (s *operationServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	ctx = s.withOperation(ctx, req.TypeName, sentryclient.OperationPlan)
+
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	data := s.providerData()
+	if data == nil || data.TokenInfo == nil || !s.changes(ctx, req.TypeName, req.PriorState, resp.PlannedState) {
+		return resp, nil
+	}
+
+	if missing := data.MissingScopes(req.TypeName); len(missing) > 0 {
+		severity := tfprotov6.DiagnosticSeverityWarning
+		if data.TokenScopeCheck == providerdata.TokenScopeCheckError {
+			severity = tfprotov6.DiagnosticSeverityError
+		}
+
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{
+			Severity: severity,
+			Summary:  "Missing token scopes",
+			Detail:   sentryclient.MissingScopesMessage(req.TypeName, missing, data.TokenInfo.Type),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *operationServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	name := sentryclient.OperationUpdate
+	if isNull(req.PriorState) {
+		name = sentryclient.OperationCreate
+	} else if isNull(req.PlannedState) {
+		name = sentryclient.OperationDelete
+	}
+
+	return s.ProviderServer.ApplyResourceChange(s.withOperation(ctx, req.TypeName, name), req)
+}
+
+func (s *operationServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	return s.ProviderServer.ReadResource(s.withOperation(ctx, req.TypeName, sentryclient.OperationRead), req)
+}
+
+func (s *operationServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	return s.ProviderServer.ImportResourceState(s.withOperation(ctx, req.TypeName, sentryclient.OperationImport), req)
+}
+
+func (s *operationServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	return s.ProviderServer.ReadDataSource(s.withOperation(ctx, req.TypeName, sentryclient.OperationRead), req)
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryownership"
+)
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string is a valid Go duration such as
+// "30s" or "1m30s".
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a duration string such as `30s` or `1m30s`"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			fmt.Sprintf("%s, got: %s", v.Description(ctx), err),
+		)
+	}
+}
+
+var _ validator.String = ownershipRulesValidator{}
+
+// ownershipRulesValidator validates the syntax of the ownership rules of a
+// project, such as `path:src/billing/* #billing jane@example.com`.
+type ownershipRulesValidator struct{}
+
+func (v ownershipRulesValidator) Description(ctx context.Context) string {
+	return "value must be ownership rules such as `path:src/billing/* #billing jane@example.com`"
+}
+
+func (v ownershipRulesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ownershipRulesValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := sentryownership.Parse(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid ownership rules",
+			fmt.Sprintf("%s, got: %s", v.Description(ctx), err),
+		)
+	}
+}
@@ -0,0 +1,61 @@
+package providerdata
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+)
+
+// adoptClockSkew is how long before a create started an adopted object may
+// have been created, to allow for the clocks of the provider and Sentry to
+// differ.
+const adoptClockSkew = time.Minute
+
+// CreateOnce creates the object of a resource with create, without creating
+// a duplicate when the request fails in a way that leaves it unknown whether
+// Sentry created it, see sentryclient.IsAmbiguous.
+//
+// After such a failure, find looks up the object by its natural key, such as
+// its name and project, among the objects created since the given time. A
+// found object is adopted instead of creating another one. find returns nil
+// if there is no such object, in which case the object is created again.
+//
+// Objects are only adopted if the `adopt_on_ambiguous_create` provider
+// attribute is enabled, otherwise requests are retried as usual.
+func CreateOnce[T any](ctx context.Context, d *ProviderData, name string, create func(client *sentry.Client) (*T, error), find func(client *sentry.Client, since time.Time) (*T, error)) (*T, error) {
+	if !d.AdoptOnAmbiguousCreate {
+		return create(d.ClientFor(ctx))
+	}
+
+	start := time.Now()
+	v, err := create(d.ClientFor(sentryclient.WithoutAmbiguousRetries(ctx)))
+	if err == nil || !sentryclient.IsAmbiguous(err) || ctx.Err() != nil {
+		return v, err
+	}
+
+	tflog.Info(ctx, "Looking up the object of a create that may have succeeded", map[string]interface{}{
+		"resource": name,
+		"error":    err.Error(),
+	})
+	found, findErr := find(d.ClientFor(ctx), start.Add(-adoptClockSkew))
+	if findErr != nil {
+		tflog.Warn(ctx, "Unable to look up the object of a create that may have succeeded", map[string]interface{}{
+			"resource": name,
+			"error":    findErr.Error(),
+		})
+		return nil, err
+	}
+	if found != nil {
+		tflog.Warn(ctx, "Adopting the object of a create that failed but succeeded in Sentry", map[string]interface{}{
+			"resource": name,
+			"error":    err.Error(),
+		})
+		return found, nil
+	}
+
+	// Nothing was created, so create the object again with the usual retries
+	return create(d.ClientFor(ctx))
+}
@@ -0,0 +1,43 @@
+package providerdata
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	locksMu sync.Mutex
+	// locks are 1-slot channels, holding a value while locked.
+	locks = map[string]chan struct{}{}
+)
+
+// Lock locks key for the resources of both providers, such as a document that
+// several resources read, modify and write, and returns the function that
+// unlocks it. It gives up with the error of ctx if ctx is done before the
+// lock is acquired, so that waiting for the lock counts against the timeout
+// of the operation.
+func Lock(ctx context.Context, key string) (unlock func(), err error) {
+	locksMu.Lock()
+	lock, ok := locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		locks[key] = lock
+	}
+	locksMu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// RelayPiiConfigLockKey is the key that locks the data scrubbing rules of an
+// organization, or of a project if project is not empty.
+func RelayPiiConfigLockKey(organization string, project string) string {
+	if project == "" {
+		return "relay-pii-config/" + organization
+	}
+	return "relay-pii-config/" + organization + "/" + project
+}
@@ -0,0 +1,49 @@
+package providerdata
+
+import (
+	"context"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+)
+
+// ProviderData is the data shared by the framework and SDKv2 providers with
+// their resources and data sources.
+type ProviderData struct {
+	Client *sentry.Client
+
+	// DefaultOrganization is the organization slug used when a resource or
+	// data source does not set `organization`.
+	DefaultOrganization string
+
+	// Cache caches organization-wide list calls made with Client.
+	Cache *sentryclient.Cache
+
+	// TokenScopeCheck is the `token_scope_check` mode, and TokenInfo the
+	// introspected token if the mode is not TokenScopeCheckOff.
+	TokenScopeCheck string
+	TokenInfo       *sentryclient.TokenInfo
+
+	// DeletionProtection is the default `deletion_protection` of the
+	// resources that do not set it.
+	DeletionProtection bool
+
+	// AdoptOnAmbiguousCreate is the `adopt_on_ambiguous_create` attribute,
+	// see CreateOnce.
+	AdoptOnAmbiguousCreate bool
+}
+
+// ClientFor returns Client with its requests attributed to the Terraform
+// operation in ctx.
+func (d *ProviderData) ClientFor(ctx context.Context) *sentry.Client {
+	return sentryclient.WithContext(ctx, d.Client)
+}
+
+// MissingScopes returns the scopes required by a resource type that the token
+// does not have. It returns nil if the token scopes are not checked.
+func (d *ProviderData) MissingScopes(typeName string) []string {
+	if d == nil || d.TokenInfo == nil {
+		return nil
+	}
+	return d.TokenInfo.MissingScopes(RequiredScopes[typeName])
+}
@@ -0,0 +1,39 @@
+package providerdata
+
+// Modes of the `token_scope_check` provider attribute.
+const (
+	TokenScopeCheckOff   = "off"
+	TokenScopeCheckWarn  = "warn"
+	TokenScopeCheckError = "error"
+)
+
+// RequiredScopes are the token scopes each resource needs to be created and
+// updated. Higher scope levels grant the lower ones, see
+// sentryclient.TokenInfo.MissingScopes.
+var RequiredScopes = map[string][]string{
+	// Framework resources
+	"sentry_all_projects_spike_protection":    {"project:write"},
+	"sentry_integration_opsgenie":             {"org:integrations"},
+	"sentry_integration_pagerduty":            {"org:integrations"},
+	"sentry_issue_alert":                      {"alerts:write"},
+	"sentry_key":                              {"project:write"},
+	"sentry_notification_action":              {"org:write"},
+	"sentry_organization_data_scrubbing_rule": {"org:write"},
+	"sentry_project_data_scrubbing_rule":      {"project:write"},
+	"sentry_project_inbound_data_filter":      {"project:write"},
+	"sentry_project_ownership":                {"project:write", "team:read", "member:read"},
+	"sentry_project_spike_protection":         {"project:write"},
+	"sentry_project_symbol_source":            {"project:write"},
+	"sentry_team_member":                      {"team:write", "member:read"},
+
+	// SDKv2 resources
+	"sentry_dashboard":                      {"org:read"},
+	"sentry_metric_alert":                   {"alerts:write"},
+	"sentry_organization":                   {"org:write"},
+	"sentry_organization_code_mapping":      {"org:integrations"},
+	"sentry_organization_member":            {"member:write"},
+	"sentry_organization_repository_github": {"org:integrations"},
+	"sentry_plugin":                         {"project:write"},
+	"sentry_project":                        {"project:write"},
+	"sentry_team":                           {"team:write"},
+}
@@ -0,0 +1,13 @@
+package providerdata
+
+import "time"
+
+// Default timeouts of the operations of resources, used unless they are set
+// in the `timeouts` block of a resource. An operation may make several
+// requests, each retried and waiting for rate limits to reset.
+const (
+	DefaultCreateTimeout = 10 * time.Minute
+	DefaultReadTimeout   = 5 * time.Minute
+	DefaultUpdateTimeout = 10 * time.Minute
+	DefaultDeleteTimeout = 10 * time.Minute
+)
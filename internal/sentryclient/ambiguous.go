@@ -0,0 +1,50 @@
+package sentryclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+type noAmbiguousRetriesContextKey struct{}
+
+// WithoutAmbiguousRetries returns a copy of ctx whose requests are not retried
+// after a failure that leaves it unknown whether Sentry processed them, see
+// IsAmbiguous. Rate limited requests are still retried, as Sentry rejected
+// them.
+//
+// Creates use it to look up the object they may have created before posting
+// again, which would create a duplicate.
+func WithoutAmbiguousRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noAmbiguousRetriesContextKey{}, true)
+}
+
+func ambiguousRetriesDisabled(ctx context.Context) bool {
+	v, _ := ctx.Value(noAmbiguousRetriesContextKey{}).(bool)
+	return v
+}
+
+// IsAmbiguous reports whether err leaves it unknown whether Sentry processed
+// the request: the request timed out, the connection failed or Sentry
+// responded with a server error.
+func IsAmbiguous(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var readOnlyErr *ReadOnlyError
+	if errors.As(err, &readOnlyErr) {
+		return false
+	}
+
+	var errResp *sentry.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response != nil && errResp.Response.StatusCode >= http.StatusInternalServerError
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,108 @@
+package sentryclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func TestIsAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	errorResponse := func(statusCode int) error {
+		return &sentry.ErrorResponse{Response: &http.Response{StatusCode: statusCode}}
+	}
+
+	testCases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"nil": {
+			err:  nil,
+			want: false,
+		},
+		"server error": {
+			err:  errorResponse(http.StatusBadGateway),
+			want: true,
+		},
+		"client error": {
+			err:  errorResponse(http.StatusBadRequest),
+			want: false,
+		},
+		"connection error": {
+			err:  &url.Error{Op: "Post", URL: "https://sentry.io/api/0/", Err: errors.New("connection reset by peer")},
+			want: true,
+		},
+		"canceled": {
+			err:  &url.Error{Op: "Post", URL: "https://sentry.io/api/0/", Err: context.Canceled},
+			want: false,
+		},
+		"read-only": {
+			err:  &url.Error{Op: "Post", URL: "https://sentry.io/api/0/", Err: &ReadOnlyError{Method: http.MethodPost, Path: "/api/0/"}},
+			want: false,
+		},
+		"wrapped": {
+			err:  fmt.Errorf("create: %w", errorResponse(http.StatusServiceUnavailable)),
+			want: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsAmbiguous(testCase.err); got != testCase.want {
+				t.Errorf("got %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestConfigCheckRetryWithoutAmbiguousRetries(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithoutAmbiguousRetries(context.Background())
+	c := &Config{}
+
+	testCases := map[string]struct {
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		"server error": {
+			resp: &http.Response{StatusCode: http.StatusBadGateway},
+			want: false,
+		},
+		"connection error": {
+			err:  errors.New("connection reset by peer"),
+			want: false,
+		},
+		"rate limited": {
+			resp: &http.Response{StatusCode: http.StatusTooManyRequests, Body: http.NoBody},
+			want: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := c.checkRetry(ctx, testCase.resp, testCase.err)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != testCase.want {
+				t.Errorf("got %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package sentryclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// requestIDHeaders are the response headers Sentry may report the ID of a
+// request in, in order of preference.
+var requestIDHeaders = []string{
+	"X-Sentry-Request-Id",
+	"X-Request-Id",
+}
+
+// AuditEntry is a line of the audit log.
+type AuditEntry struct {
+	Time time.Time `json:"time"`
+
+	// ResourceType and Operation are the Terraform resource type and
+	// operation the request was made for, if known.
+	ResourceType string `json:"resource_type,omitempty"`
+	Operation    string `json:"operation,omitempty"`
+
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	StatusCode int    `json:"status_code,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+	// RequestBody is the request body with secrets redacted, as JSON if the
+	// body is JSON and as a string otherwise.
+	RequestBody interface{} `json:"request_body,omitempty"`
+	// Error is set if no response was received.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditLog appends the requests that change Sentry to a file, as JSON lines.
+//
+// The file is opened for each line and closed once the line is written, so
+// that no line is lost when Terraform kills the provider at the end of an
+// operation.
+type AuditLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+var (
+	auditLogsMu sync.Mutex
+	auditLogs   = map[string]*AuditLog{}
+)
+
+// OpenAuditLog opens the audit log at path for appending, creating it if
+// needed to check that it can be written. The muxed providers share the
+// AuditLog of a path, so that their lines are never interleaved.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLogsMu.Lock()
+	defer auditLogsMu.Unlock()
+
+	if l, ok := auditLogs[path]; ok {
+		return l, nil
+	}
+
+	file, err := openAuditLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	l := &AuditLog{path: path}
+	auditLogs[path] = l
+	return l, nil
+}
+
+// Write appends entry to the audit log.
+func (l *AuditLog) Write(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := openAuditLogFile(l.path)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(line); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func openAuditLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+}
+
+// auditTransport records the requests that change Sentry in an AuditLog, once
+// they and their retries are done.
+type auditTransport struct {
+	Delegate http.RoundTripper
+	AuditLog *AuditLog
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.Delegate.RoundTrip(req)
+	}
+
+	entry := AuditEntry{
+		Time:   time.Now().UTC(),
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+	if op, ok := OperationFromContext(req.Context()); ok {
+		entry.ResourceType = op.TypeName
+		entry.Operation = op.Name
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		if redactedBody := redactBody(body); json.Valid([]byte(redactedBody)) {
+			entry.RequestBody = json.RawMessage(redactedBody)
+		} else {
+			entry.RequestBody = redactedBody
+		}
+	}
+
+	resp, err := t.Delegate.RoundTrip(req)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.StatusCode = resp.StatusCode
+		entry.RequestID = requestID(resp)
+	}
+
+	// The request has been made, so failing to record it must not fail it
+	if auditErr := t.AuditLog.Write(entry); auditErr != nil {
+		tflog.Error(req.Context(), "Failed to write to the audit log", map[string]interface{}{
+			"error": auditErr.Error(),
+		})
+	}
+
+	return resp, err
+}
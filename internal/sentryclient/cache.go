@@ -0,0 +1,275 @@
+package sentryclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+)
+
+// projectListOptions are the project options included when listing projects,
+// so that a single listing serves every resource that reads them.
+const projectListOptions = "quotas:spike-protection-disabled"
+
+// Cache caches organization-wide list calls for the lifetime of the provider,
+// so that resources and data sources reading the same list within a Terraform
+// operation hit the API once. Concurrent calls for the same list share a single
+// request, and the whole cache is invalidated by every write made by a client
+// configured with it.
+//
+// The clients passed to its methods must be created by Config.Client rather
+// than bound to an operation by WithContext: the shared requests are bound to
+// the context of the caller that starts them, without its cancellation.
+//
+// The returned values are shared and must not be modified. A nil *Cache is
+// valid and does not cache.
+type Cache struct {
+	group singleflight.Group
+
+	mu         sync.Mutex
+	generation uint64
+	entries    map[string]interface{}
+}
+
+var (
+	sharedCachesMu sync.Mutex
+	sharedCaches   = map[string]*Cache{}
+)
+
+// SharedCache returns the Cache shared by all clients of the Sentry server at
+// baseURL authenticating with the same token or token source, so that writes
+// made through either of the muxed providers invalidate the lists read by the
+// other. Clients of other tokens, such as those of provider aliases, do not
+// share lists they may not be allowed to read.
+func SharedCache(baseURL string, token string, tokenSource oauth2.TokenSource) *Cache {
+	sharedCachesMu.Lock()
+	defer sharedCachesMu.Unlock()
+
+	key := credentialsKey(baseURL, token, tokenSource)
+	cache, ok := sharedCaches[key]
+	if !ok {
+		cache = &Cache{}
+		sharedCaches[key] = cache
+	}
+	return cache
+}
+
+// InvalidateSharedCaches drops the lists cached by all shared caches.
+func InvalidateSharedCaches() {
+	sharedCachesMu.Lock()
+	defer sharedCachesMu.Unlock()
+
+	for _, cache := range sharedCaches {
+		cache.Invalidate()
+	}
+}
+
+// Invalidate drops all cached lists.
+func (c *Cache) Invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = nil
+}
+
+// cached returns the cached value for key, or calls fetch. The result is only
+// stored if no write happened while fetching it.
+func cached[T any](ctx context.Context, c *Cache, key string, fetch func(ctx context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	c.mu.Lock()
+	if v, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		return v.(T), nil
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	// Callers arriving after a write must not share a request started before it.
+	ch := c.group.DoChan(fmt.Sprintf("%d/%s", generation, key), func() (interface{}, error) {
+		// The request is shared, so it must not be canceled with the caller
+		// that happened to start it. The fetch binds the client to this
+		// context, see WithContext.
+		v, err := fetch(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.generation == generation {
+			if c.entries == nil {
+				c.entries = make(map[string]interface{})
+			}
+			c.entries[key] = v
+		}
+		return v, nil
+	})
+
+	// Stop waiting at the caller's own deadline, leaving the shared request to
+	// the other callers.
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
+
+// Projects lists the projects of an organization.
+func (c *Cache) Projects(ctx context.Context, client *sentry.Client, organization string) ([]*sentry.Project, error) {
+	return cached(ctx, c, "projects/"+organization, func(ctx context.Context) ([]*sentry.Project, error) {
+		client := WithContext(ctx, client)
+
+		var allProjects []*sentry.Project
+		params := &sentry.ListOrganizationProjectsParams{
+			Options: projectListOptions,
+		}
+
+		for {
+			projects, resp, err := client.OrganizationProjects.List(ctx, organization, params)
+			if err != nil {
+				return nil, err
+			}
+			allProjects = append(allProjects, projects...)
+
+			if resp.Cursor == "" {
+				break
+			}
+			params.Cursor = resp.Cursor
+		}
+
+		return allProjects, nil
+	})
+}
+
+// Teams lists the teams of an organization.
+func (c *Cache) Teams(ctx context.Context, client *sentry.Client, organization string) ([]*sentry.Team, error) {
+	return cached(ctx, c, "teams/"+organization, func(ctx context.Context) ([]*sentry.Team, error) {
+		client := WithContext(ctx, client)
+
+		var allTeams []*sentry.Team
+		params := &sentry.ListCursorParams{}
+
+		for {
+			teams, resp, err := client.Teams.List(ctx, organization, params)
+			if err != nil {
+				return nil, err
+			}
+			allTeams = append(allTeams, teams...)
+
+			if resp.Cursor == "" {
+				break
+			}
+			params.Cursor = resp.Cursor
+		}
+
+		return allTeams, nil
+	})
+}
+
+// Members lists the members of an organization.
+func (c *Cache) Members(ctx context.Context, client *sentry.Client, organization string) ([]*sentry.OrganizationMember, error) {
+	return cached(ctx, c, "members/"+organization, func(ctx context.Context) ([]*sentry.OrganizationMember, error) {
+		client := WithContext(ctx, client)
+
+		var allMembers []*sentry.OrganizationMember
+		params := &sentry.ListCursorParams{}
+
+		for {
+			members, resp, err := client.OrganizationMembers.List(ctx, organization, params)
+			if err != nil {
+				return nil, err
+			}
+			allMembers = append(allMembers, members...)
+
+			if resp.Cursor == "" {
+				break
+			}
+			params.Cursor = resp.Cursor
+		}
+
+		return allMembers, nil
+	})
+}
+
+// Integrations lists the integrations of an organization for all providers.
+func (c *Cache) Integrations(ctx context.Context, client *sentry.Client, organization string) ([]*sentry.OrganizationIntegration, error) {
+	return cached(ctx, c, "integrations/"+organization, func(ctx context.Context) ([]*sentry.OrganizationIntegration, error) {
+		client := WithContext(ctx, client)
+
+		var allIntegrations []*sentry.OrganizationIntegration
+		params := &sentry.ListOrganizationIntegrationsParams{}
+
+		for {
+			integrations, resp, err := client.OrganizationIntegrations.List(ctx, organization, params)
+			if err != nil {
+				return nil, err
+			}
+			allIntegrations = append(allIntegrations, integrations...)
+
+			if resp.Cursor == "" {
+				break
+			}
+			params.Cursor = resp.Cursor
+		}
+
+		return allIntegrations, nil
+	})
+}
+
+// Roles holds the organization and team roles of an organization.
+type Roles struct {
+	OrgRoles  []sentry.OrganizationRoleListItem
+	TeamRoles []sentry.TeamRoleListItem
+}
+
+// Roles reads the roles of an organization.
+func (c *Cache) Roles(ctx context.Context, client *sentry.Client, organization string) (*Roles, error) {
+	return cached(ctx, c, "roles/"+organization, func(ctx context.Context) (*Roles, error) {
+		client := WithContext(ctx, client)
+
+		org, _, err := client.Organizations.Get(ctx, organization)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Roles{
+			OrgRoles:  org.OrgRoleList,
+			TeamRoles: org.TeamRoleList,
+		}, nil
+	})
+}
+
+// cacheInvalidatingTransport invalidates Cache after every write request,
+// whether or not it succeeded.
+type cacheInvalidatingTransport struct {
+	Delegate http.RoundTripper
+
+	Cache *Cache
+}
+
+func (t *cacheInvalidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.Delegate.RoundTrip(req)
+	}
+
+	defer t.Cache.Invalidate()
+	return t.Delegate.RoundTrip(req)
+}
@@ -0,0 +1,237 @@
+package sentryclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func TestCacheProjects(t *testing.T) {
+	t.Parallel()
+
+	var lists atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/0/organizations/my-org/projects/":
+			lists.Add(1)
+			if r.URL.Query().Get("options") != projectListOptions {
+				t.Errorf("unexpected options: %q", r.URL.Query().Get("options"))
+			}
+			time.Sleep(20 * time.Millisecond)
+			if r.URL.Query().Get("cursor") == "" {
+				w.Header().Set("Link", `<`+r.URL.String()+`&cursor=1>; rel="next"; results="true"; cursor="1"`)
+				_, _ = w.Write([]byte(`[{"id":"1","slug":"project-1"}]`))
+			} else {
+				_, _ = w.Write([]byte(`[{"id":"2","slug":"project-2"}]`))
+			}
+		case r.Method == http.MethodPut && r.URL.Path == "/api/0/projects/my-org/project-1/":
+			_, _ = w.Write([]byte(`{"id":"1","slug":"project-1"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	cache := &Cache{}
+	c := &Config{
+		BaseURL: srv.URL + "/api/",
+		Cache:   cache,
+	}
+	client, err := c.Client(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Concurrent reads share a single listing
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			projectMap, err := GetProjectIdToSlugMap(ctx, cache, client, "my-org")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if len(projectMap) != 2 || projectMap["1"] != "project-1" || projectMap["2"] != "project-2" {
+				t.Errorf("unexpected projects: %v", projectMap)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := cache.Projects(ctx, client, "my-org"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := lists.Load(); got != 2 {
+		t.Errorf("got %d list requests, want 2", got)
+	}
+
+	// Writes invalidate the cache
+	if _, _, err := client.Projects.Update(ctx, "my-org", "project-1", &sentry.UpdateProjectParams{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := cache.Projects(ctx, client, "my-org"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := lists.Load(); got != 4 {
+		t.Errorf("got %d list requests, want 4", got)
+	}
+}
+
+func TestCacheDiscardsListsStaleByWrite(t *testing.T) {
+	t.Parallel()
+
+	cache := &Cache{}
+	ctx := context.Background()
+
+	var fetches int
+	fetch := func(ctx context.Context) (int, error) {
+		fetches++
+		// A write completes while the list is in flight
+		if fetches == 1 {
+			cache.Invalidate()
+		}
+		return fetches, nil
+	}
+
+	for want := 1; want <= 2; want++ {
+		got, err := cached(ctx, cache, "key", fetch)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != want {
+			t.Errorf("got %d, want %d", got, want)
+		}
+	}
+
+	if got, _ := cached(ctx, cache, "key", fetch); got != 2 {
+		t.Errorf("got %d, want the cached 2", got)
+	}
+}
+
+func TestCacheRespectsCallerDeadline(t *testing.T) {
+	t.Parallel()
+
+	cache := &Cache{}
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := cached(ctx, cache, "key", func(ctx context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	t.Parallel()
+
+	var cache *Cache
+	cache.Invalidate()
+
+	var fetches int
+	for i := 0; i < 2; i++ {
+		if _, err := cached(context.Background(), cache, "key", func(ctx context.Context) (int, error) {
+			fetches++
+			return fetches, nil
+		}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if fetches != 2 {
+		t.Errorf("got %d fetches, want 2", fetches)
+	}
+}
+
+func TestCacheSharedRequestOutlivesCaller(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"1","slug":"team-1"}]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	var typeName atomic.Value
+	cache := &Cache{}
+	c := &Config{
+		BaseURL: srv.URL + "/api/",
+		Cache:   cache,
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				op, _ := OperationFromContext(req.Context())
+				typeName.Store(op.TypeName)
+				return rt.RoundTrip(req)
+			})
+		},
+	}
+	client, err := c.Client(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The caller starting the request gives up before it completes
+	ctx := ContextWithOperation(context.Background(), Operation{TypeName: "sentry_team", Name: OperationRead})
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	first := make(chan error, 1)
+	go func() {
+		_, err := cache.Teams(ctx, client, "my-org")
+		first <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	teams, err := cache.Teams(context.Background(), client, "my-org")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(teams) != 1 {
+		t.Errorf("got teams %v", teams)
+	}
+	if err := <-first; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// The request is attributed to the operation of the caller starting it
+	if got := typeName.Load(); got != "sentry_team" {
+		t.Errorf("got request for %v, want sentry_team", got)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestSharedCache(t *testing.T) {
+	t.Parallel()
+
+	baseURL := "https://TestSharedCache.example.com/api/"
+	if SharedCache(baseURL, "token-1", nil) != SharedCache(baseURL, "token-1", nil) {
+		t.Error("clients of the same token do not share a cache")
+	}
+	if SharedCache(baseURL, "token-1", nil) == SharedCache(baseURL, "token-2", nil) {
+		t.Error("clients of different tokens share a cache")
+	}
+	if SharedCache("", "token-1", nil) == SharedCache(baseURL, "token-1", nil) {
+		t.Error("clients of different servers share a cache")
+	}
+}
@@ -0,0 +1,143 @@
+package sentryclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultTokenCommandTTL is how long a token printed by a token command is
+// cached when no TTL is configured.
+const DefaultTokenCommandTTL = 5 * time.Minute
+
+// TokenCommandTimeout is how long a token command may run before it is
+// killed, so that a hanging command fails the requests waiting for it rather
+// than blocking them forever.
+const TokenCommandTimeout = 30 * time.Second
+
+// ReadTokenFile reads the authentication token from the file at path.
+func ReadTokenFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(b))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+var (
+	commandTokensMu    sync.Mutex
+	commandTokens      = map[string]*oauth2.Token{}
+	commandTokensGroup singleflight.Group
+)
+
+// NewCommandTokenSource returns a token source that runs the command described
+// by args and reads the token from its stdout. Tokens are cached for ttl, and
+// the cache is shared by all token sources running the same command so the
+// muxed providers only run it once.
+func NewCommandTokenSource(args []string, ttl time.Duration) oauth2.TokenSource {
+	if ttl <= 0 {
+		ttl = DefaultTokenCommandTTL
+	}
+	return &commandTokenSource{args: args, ttl: ttl, timeout: TokenCommandTimeout}
+}
+
+type commandTokenSource struct {
+	args    []string
+	ttl     time.Duration
+	timeout time.Duration
+}
+
+func (s *commandTokenSource) Token() (*oauth2.Token, error) {
+	if len(s.args) == 0 {
+		return nil, errors.New("token command must not be empty")
+	}
+
+	key := strings.Join(s.args, "\x00")
+
+	commandTokensMu.Lock()
+	token, ok := commandTokens[key]
+	commandTokensMu.Unlock()
+	if ok && token.Valid() {
+		return token, nil
+	}
+
+	// Concurrent callers share a single run of the command
+	v, err, _ := commandTokensGroup.Do(key, func() (interface{}, error) {
+		token, err := s.run()
+		if err != nil {
+			return nil, err
+		}
+
+		commandTokensMu.Lock()
+		commandTokens[key] = token
+		commandTokensMu.Unlock()
+		return token, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*oauth2.Token), nil
+}
+
+func (s *commandTokenSource) run() (*oauth2.Token, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.args[0], s.args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Do not wait for the output of the processes started by the command once
+	// it has been killed
+	cmd.WaitDelay = time.Second
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("token command did not finish within %s", s.timeout)
+		}
+		return nil, fmt.Errorf("token command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	accessToken := strings.TrimSpace(stdout.String())
+	if accessToken == "" {
+		return nil, errors.New("token command printed an empty token")
+	}
+
+	return &oauth2.Token{
+		AccessToken: accessToken,
+		Expiry:      time.Now().Add(s.ttl),
+	}, nil
+}
+
+// credentialsKey identifies the server and the credentials of a client, so
+// that the state shared by clients is only shared by the clients of the same
+// token. Tokens are hashed rather than kept as keys.
+func credentialsKey(baseURL string, token string, tokenSource oauth2.TokenSource) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", baseURL)
+	switch ts := tokenSource.(type) {
+	case nil:
+		fmt.Fprintf(h, "token\x00%s", token)
+	case *commandTokenSource:
+		// Token sources running the same command share their tokens
+		fmt.Fprintf(h, "command\x00%s", strings.Join(ts.args, "\x00"))
+	default:
+		fmt.Fprintf(h, "source\x00%p", ts)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
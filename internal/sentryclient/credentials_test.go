@@ -0,0 +1,81 @@
+package sentryclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadTokenFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "token")
+	if err := os.WriteFile(path, []byte("my-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadTokenFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != "my-token" {
+		t.Errorf("got %q, want %q", got, "my-token")
+	}
+
+	emptyPath := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyPath, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadTokenFile(emptyPath); err == nil {
+		t.Error("expected an error for an empty token file")
+	}
+}
+
+func TestCommandTokenSource(t *testing.T) {
+	t.Parallel()
+
+	counter := filepath.Join(t.TempDir(), "counter")
+	args := []string{"sh", "-c", `echo x >> "$0"; echo " my-token "`, counter}
+
+	for i := 0; i < 2; i++ {
+		token, err := NewCommandTokenSource(args, time.Hour).Token()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if token.AccessToken != "my-token" {
+			t.Errorf("got %q, want %q", token.AccessToken, "my-token")
+		}
+	}
+
+	b, err := os.ReadFile(counter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(b) / len("x\n"); got != 1 {
+		t.Errorf("got %d command runs, want 1", got)
+	}
+}
+
+func TestCommandTokenSourceFailure(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewCommandTokenSource([]string{"sh", "-c", "exit 1"}, time.Hour).Token(); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestCommandTokenSourceTimeout(t *testing.T) {
+	t.Parallel()
+
+	s := &commandTokenSource{args: []string{"sh", "-c", "sleep 60"}, ttl: time.Hour, timeout: 100 * time.Millisecond}
+
+	start := time.Now()
+	if _, err := s.Token(); err == nil {
+		t.Error("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("got %s, want the command to be killed after its timeout", elapsed)
+	}
+}
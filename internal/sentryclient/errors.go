@@ -0,0 +1,208 @@
+package sentryclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// nonFieldErrorKeys are the keys of error response bodies holding the errors
+// of the object they are in, rather than of a field.
+var nonFieldErrorKeys = map[string]struct{}{
+	"detail":           {},
+	"nonFieldErrors":   {},
+	"non_field_errors": {},
+	"__all__":          {},
+}
+
+// ErrorDetails are the details of an error response of the Sentry API.
+type ErrorDetails struct {
+	StatusCode int
+	// RequestID identifies the request in Sentry support tickets, if Sentry
+	// reported it.
+	RequestID string
+	// Messages are the errors that are not about a field.
+	Messages []string
+	// FieldErrors are the validation errors of the fields of the request
+	// body, ordered by field.
+	FieldErrors []FieldError
+}
+
+// FieldError is a validation error of a field of a request body.
+type FieldError struct {
+	// Path is the path to the field, made of object keys and list indexes,
+	// e.g. `triggers`, `0`, `alertThreshold`.
+	Path    []string
+	Message string
+}
+
+// ParseErrorResponse returns the details of err, if it is an error response
+// of the Sentry API. Field errors are only decoded from 400 Bad Request
+// responses, such as `{"name": ["This field is required."]}`.
+func ParseErrorResponse(err error) (*ErrorDetails, bool) {
+	var resp *http.Response
+	var errResp *sentry.ErrorResponse
+	var rateLimitErr *sentry.RateLimitError
+	switch {
+	case errors.As(err, &errResp):
+		resp = errResp.Response
+	case errors.As(err, &rateLimitErr):
+		resp = rateLimitErr.Response
+	}
+	if resp == nil {
+		return nil, false
+	}
+
+	details := &ErrorDetails{
+		StatusCode: resp.StatusCode,
+		RequestID:  requestID(resp),
+	}
+	if resp.StatusCode != http.StatusBadRequest || resp.Body == nil {
+		return details, true
+	}
+
+	// go-sentry restores the body after reading it, keep it readable
+	data, err := io.ReadAll(resp.Body)
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return details, true
+	}
+
+	var body interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return details, true
+	}
+	details.collect(nil, body)
+	return details, true
+}
+
+// IsNotFound reports whether err is a 404 Not Found response of the Sentry
+// API, such as for an object that has been deleted outside of Terraform.
+func IsNotFound(err error) bool {
+	details, ok := ParseErrorResponse(err)
+	return ok && details.StatusCode == http.StatusNotFound
+}
+
+func (d *ErrorDetails) collect(path []string, v interface{}) {
+	switch v := v.(type) {
+	case string:
+		if len(path) == 0 {
+			d.Messages = append(d.Messages, v)
+		} else {
+			d.FieldErrors = append(d.FieldErrors, FieldError{Path: path, Message: v})
+		}
+	case []interface{}:
+		for i, item := range v {
+			if _, ok := item.(map[string]interface{}); ok {
+				d.collect(appendPath(path, strconv.Itoa(i)), item)
+			} else {
+				d.collect(path, item)
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if _, ok := nonFieldErrorKeys[key]; ok {
+				d.collect(path, v[key])
+			} else {
+				d.collect(appendPath(path, key), v[key])
+			}
+		}
+	case nil:
+	default:
+		data, _ := json.Marshal(v)
+		d.collect(path, string(data))
+	}
+}
+
+// appendPath returns a copy of path with key appended, so that sibling paths
+// do not share their backing array.
+func appendPath(path []string, key string) []string {
+	return append(path[:len(path):len(path)], key)
+}
+
+// AttributePath returns the path of the Terraform attribute a field of a
+// request body was set from, made of attribute names and list indexes.
+//
+// fieldPaths maps dotted field paths, with list indexes replaced by `*`, to
+// the dotted attribute paths they were set from, such as `triggers` to
+// `trigger`. The longest mapped prefix of the field path is replaced, and the
+// remaining field names are converted from camelCase to snake_case.
+func AttributePath(fieldPath []string, fieldPaths map[string]string) []string {
+	pattern := make([]string, len(fieldPath))
+	var indexes []string
+	for i, segment := range fieldPath {
+		if isIndex(segment) {
+			pattern[i] = "*"
+			indexes = append(indexes, segment)
+		} else {
+			pattern[i] = segment
+		}
+	}
+
+	var attributePath []string
+	rest := fieldPath
+	for n := len(pattern); n > 0; n-- {
+		mapped, ok := fieldPaths[strings.Join(pattern[:n], ".")]
+		if !ok {
+			continue
+		}
+
+		for _, segment := range strings.Split(mapped, ".") {
+			if segment == "*" && len(indexes) > 0 {
+				segment, indexes = indexes[0], indexes[1:]
+			}
+			attributePath = append(attributePath, segment)
+		}
+		rest = fieldPath[n:]
+		break
+	}
+
+	for _, segment := range rest {
+		attributePath = append(attributePath, snakeCase(segment))
+	}
+	return attributePath
+}
+
+func isIndex(segment string) bool {
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
+
+// snakeCase converts a camelCase field name to snake_case.
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// requestID returns the ID Sentry reported for the request of resp.
+func requestID(resp *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if v := resp.Header.Get(header); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package sentryclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func TestParseErrorResponse(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Sentry-Request-Id", "req-1")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{
+			"name": ["This field is required."],
+			"triggers": [{}, {"alertThreshold": ["Must be a number."], "nonFieldErrors": ["Invalid trigger."]}],
+			"nonFieldErrors": ["Invalid alert rule."]
+		}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := sentry.NewOnPremiseClient(srv.URL+"/api/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, _, err = client.MetricAlerts.Create(context.Background(), "my-org", "my-project", &sentry.MetricAlert{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	details, ok := ParseErrorResponse(err)
+	if !ok {
+		t.Fatalf("got %v, want an error response", err)
+	}
+	want := &ErrorDetails{
+		StatusCode: http.StatusBadRequest,
+		RequestID:  "req-1",
+		Messages:   []string{"Invalid alert rule."},
+		FieldErrors: []FieldError{
+			{Path: []string{"name"}, Message: "This field is required."},
+			{Path: []string{"triggers", "1", "alertThreshold"}, Message: "Must be a number."},
+			{Path: []string{"triggers", "1"}, Message: "Invalid trigger."},
+		},
+	}
+	if !reflect.DeepEqual(details, want) {
+		t.Errorf("got %+v, want %+v", details, want)
+	}
+
+	// The error message is unchanged
+	if !strings.Contains(err.Error(), "This field is required.") {
+		t.Errorf("got %q, want the response body", err.Error())
+	}
+}
+
+func TestParseErrorResponseNotFound(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "The requested resource does not exist"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := sentry.NewOnPremiseClient(srv.URL+"/api/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, _, err = client.Teams.Get(context.Background(), "my-org", "my-team")
+
+	details, ok := ParseErrorResponse(err)
+	if !ok {
+		t.Fatalf("got %v, want an error response", err)
+	}
+	if details.StatusCode != http.StatusNotFound || len(details.FieldErrors) != 0 {
+		t.Errorf("got %+v, want a 404 without field errors", details)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("got %v, want not found", err)
+	}
+
+	if _, ok := ParseErrorResponse(errors.New("boom")); ok {
+		t.Error("got an error response from a plain error")
+	}
+	if IsNotFound(errors.New("boom")) || IsNotFound(nil) {
+		t.Error("got not found from a plain error")
+	}
+}
+
+func TestAttributePath(t *testing.T) {
+	t.Parallel()
+
+	fieldPaths := map[string]string{
+		"triggers":           "trigger",
+		"triggers.*.actions": "trigger.*.action",
+		"widgets.*.layout":   "widget.*.layout.0",
+	}
+	testCases := []struct {
+		fieldPath []string
+		want      []string
+	}{
+		{[]string{"name"}, []string{"name"}},
+		{[]string{"timeWindow"}, []string{"time_window"}},
+		{[]string{"triggers", "1", "alertThreshold"}, []string{"trigger", "1", "alert_threshold"}},
+		{[]string{"triggers", "0", "actions", "2", "targetIdentifier"}, []string{"trigger", "0", "action", "2", "target_identifier"}},
+		{[]string{"widgets", "3", "layout", "minH"}, []string{"widget", "3", "layout", "0", "min_h"}},
+	}
+	for _, tc := range testCases {
+		if got := AttributePath(tc.fieldPath, fieldPaths); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("AttributePath(%q) = %q, want %q", tc.fieldPath, got, tc.want)
+		}
+	}
+}
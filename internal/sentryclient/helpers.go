@@ -6,25 +6,15 @@ import (
 	"github.com/jianyuan/go-sentry/v2/sentry"
 )
 
-func GetProjectIdToSlugMap(ctx context.Context, client *sentry.Client) (map[string]string, error) {
-	projectMap := make(map[string]string)
-
-	listParams := &sentry.ListProjectsParams{}
-
-	for {
-		projects, resp, err := client.Projects.List(ctx, listParams)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, project := range projects {
-			projectMap[project.ID] = project.Slug
-		}
+func GetProjectIdToSlugMap(ctx context.Context, cache *Cache, client *sentry.Client, organization string) (map[string]string, error) {
+	projects, err := cache.Projects(ctx, client, organization)
+	if err != nil {
+		return nil, err
+	}
 
-		if resp.Cursor == "" {
-			break
-		}
-		listParams.Cursor = resp.Cursor
+	projectMap := make(map[string]string, len(projects))
+	for _, project := range projects {
+		projectMap[project.ID] = project.Slug
 	}
 
 	return projectMap, nil
@@ -0,0 +1,218 @@
+package sentryclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// DefaultMaxConcurrentRequests is the concurrency used when Sentry does not
+// report a concurrency limit and none is configured.
+const DefaultMaxConcurrentRequests = 10
+
+const (
+	headerRateRemaining           = "X-Sentry-Rate-Limit-Remaining"
+	headerRateConcurrentLimit     = "X-Sentry-Rate-Limit-ConcurrentLimit"
+	headerRateConcurrentRemaining = "X-Sentry-Rate-Limit-ConcurrentRemaining"
+)
+
+// limiterTransport holds each request until limiter allows it.
+type limiterTransport struct {
+	Delegate http.RoundTripper
+
+	limiter *limiter
+}
+
+func (t *limiterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.acquire(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.Delegate.RoundTrip(req)
+	t.limiter.release(resp)
+	return resp, err
+}
+
+var (
+	sharedLimitersMu sync.Mutex
+	sharedLimiters   = map[string]*limiter{}
+)
+
+// sharedLimiter returns the limiter shared by all clients with the same
+// credentials key (see credentialsKey) and concurrency cap. Sentry limits the
+// concurrent requests of each token, so the muxed providers must not each
+// allow the full limit.
+func sharedLimiter(credentialsKey string, maxConcurrentRequests int) *limiter {
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+
+	key := fmt.Sprintf("%s/%d", credentialsKey, maxConcurrentRequests)
+	l, ok := sharedLimiters[key]
+	if !ok {
+		l = &limiter{MaxConcurrentRequests: maxConcurrentRequests}
+		sharedLimiters[key] = l
+	}
+	return l
+}
+
+// limiter adapts the number of concurrent requests to the limits reported by
+// Sentry on every response, and paces requests as the remaining requests in
+// the current rate limit window run low.
+//
+// Until the first response is received, requests are sent one at a time.
+type limiter struct {
+	// MaxConcurrentRequests caps the concurrency. If zero, the limit reported
+	// by Sentry is used, or DefaultMaxConcurrentRequests if there is none.
+	MaxConcurrentRequests int
+
+	mu       sync.Mutex
+	changed  chan struct{}
+	inFlight int
+	probed   bool
+	limit    int
+
+	// Rate limit window state, valid until reset.
+	rateLimit int
+	remaining int
+	reset     time.Time
+	lastStart time.Time
+}
+
+func (l *limiter) acquire(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		if l.changed == nil {
+			l.changed = make(chan struct{})
+		}
+
+		now := time.Now()
+		var delay time.Duration
+		if l.inFlight < l.currentLimit() {
+			delay = l.paceDelay(now)
+			if delay <= 0 {
+				l.inFlight++
+				l.lastStart = now
+				if l.remaining > 0 {
+					l.remaining--
+				}
+				l.mu.Unlock()
+				return nil
+			}
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		if deadline, ok := ctx.Deadline(); ok && delay > 0 && now.Add(delay).After(deadline) {
+			return fmt.Errorf("rate limit would not reset before the deadline: %w", context.DeadlineExceeded)
+		}
+
+		var timer *time.Timer
+		var timerC <-chan time.Time
+		if delay > 0 {
+			timer = time.NewTimer(delay)
+			timerC = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			err := ctx.Err()
+			if timer != nil {
+				timer.Stop()
+			}
+			return err
+		case <-changed:
+		case <-timerC:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+func (l *limiter) release(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resp != nil {
+		l.update(resp)
+	}
+	l.inFlight--
+
+	// Wake up the waiters
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// update records the limits reported by a response. Must be called with mu
+// held and before the request is removed from inFlight.
+func (l *limiter) update(resp *http.Response) {
+	l.probed = true
+	rate := sentry.ParseRate(resp)
+
+	if resp.Header.Get(headerRateConcurrentLimit) != "" && rate.ConcurrentLimit > 0 {
+		l.limit = rate.ConcurrentLimit
+
+		// Other clients sharing the token count against the limit too.
+		if resp.Header.Get(headerRateConcurrentRemaining) != "" {
+			if available := rate.ConcurrentRemaining + l.inFlight; available < l.limit {
+				l.limit = available
+			}
+		}
+	} else {
+		l.limit = 0
+	}
+
+	if resp.Header.Get(headerRateRemaining) != "" && !rate.Reset.IsZero() {
+		l.rateLimit = rate.Limit
+		l.remaining = rate.Remaining
+		l.reset = rate.Reset
+	}
+}
+
+// currentLimit returns the number of requests allowed in flight. Must be
+// called with mu held.
+func (l *limiter) currentLimit() int {
+	if !l.probed {
+		return 1
+	}
+
+	limit := l.limit
+	if limit <= 0 {
+		limit = DefaultMaxConcurrentRequests
+		if l.MaxConcurrentRequests > 0 {
+			limit = l.MaxConcurrentRequests
+		}
+	} else if l.MaxConcurrentRequests > 0 && limit > l.MaxConcurrentRequests {
+		limit = l.MaxConcurrentRequests
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// paceDelay returns how long to wait before starting a request so the
+// remaining requests are spread over the rest of the rate limit window. Must be
+// called with mu held.
+func (l *limiter) paceDelay(now time.Time) time.Duration {
+	if l.reset.IsZero() || !now.Before(l.reset) {
+		return 0
+	}
+
+	untilReset := l.reset.Sub(now)
+	if l.remaining <= 0 {
+		return untilReset
+	}
+
+	// Only pace once a quarter or less of the window is left.
+	if l.rateLimit > 0 && l.remaining > l.rateLimit/4 {
+		return 0
+	}
+
+	interval := untilReset / time.Duration(l.remaining+1)
+	return l.lastStart.Add(interval).Sub(now)
+}
@@ -0,0 +1,270 @@
+package sentryclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyRecorder is a RoundTripper that records the peak number of
+// concurrent requests and responds with the given headers.
+type concurrencyRecorder struct {
+	header http.Header
+	delay  time.Duration
+
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (r *concurrencyRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := r.inFlight.Add(1)
+	defer r.inFlight.Add(-1)
+	for {
+		peak := r.peak.Load()
+		if n <= peak || r.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	time.Sleep(r.delay)
+	return &http.Response{StatusCode: http.StatusOK, Header: r.header.Clone(), Body: http.NoBody}, nil
+}
+
+// header builds a canonicalized header from key-value pairs.
+func header(kv ...string) http.Header {
+	h := http.Header{}
+	for i := 0; i < len(kv); i += 2 {
+		h.Set(kv[i], kv[i+1])
+	}
+	return h
+}
+
+func runConcurrently(t *testing.T, rt http.RoundTripper, n int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, "https://sentry.io/api/0/", nil)
+			if _, err := rt.RoundTrip(req); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLimiterTransportConcurrency(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		header                http.Header
+		maxConcurrentRequests int
+		want                  int32
+	}{
+		"reported limit": {
+			header: header(headerRateConcurrentLimit, "3"),
+			want:   3,
+		},
+		"reported limit capped": {
+			header:                header(headerRateConcurrentLimit, "25"),
+			maxConcurrentRequests: 2,
+			want:                  2,
+		},
+		"no reported limit": {
+			header: header(),
+			want:   DefaultMaxConcurrentRequests,
+		},
+		"shared with other clients": {
+			header: header(
+				headerRateConcurrentLimit, "5",
+				headerRateConcurrentRemaining, "0",
+			),
+			want: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &concurrencyRecorder{header: testCase.header, delay: 20 * time.Millisecond}
+			rt := &limiterTransport{Delegate: recorder, limiter: &limiter{MaxConcurrentRequests: testCase.maxConcurrentRequests}}
+
+			// The first request probes the limits.
+			runConcurrently(t, rt, 1)
+			runConcurrently(t, rt, 20)
+
+			if got := recorder.peak.Load(); got != testCase.want {
+				t.Errorf("got peak concurrency %d, want %d", got, testCase.want)
+			}
+		})
+	}
+}
+
+type failingRoundTripper struct{}
+
+func (failingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestLimiterTransportFailedProbe(t *testing.T) {
+	t.Parallel()
+
+	rt := &limiterTransport{Delegate: failingRoundTripper{}, limiter: &limiter{}}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://sentry.io/api/0/", nil)
+		if _, err := rt.RoundTrip(req); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+
+	rt.Delegate = &concurrencyRecorder{header: header(headerRateConcurrentLimit, "4"), delay: 20 * time.Millisecond}
+	runConcurrently(t, rt, 1)
+	runConcurrently(t, rt, 8)
+
+	if got := rt.Delegate.(*concurrencyRecorder).peak.Load(); got != 4 {
+		t.Errorf("got peak concurrency %d, want 4", got)
+	}
+}
+
+func TestLimiterTransportPaceDelay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := map[string]struct {
+		rateLimit int
+		remaining int
+		reset     time.Time
+		lastStart time.Time
+		want      time.Duration
+	}{
+		"unknown": {
+			want: 0,
+		},
+		"reset passed": {
+			rateLimit: 40,
+			remaining: 0,
+			reset:     now.Add(-time.Second),
+			want:      0,
+		},
+		"exhausted": {
+			rateLimit: 40,
+			remaining: 0,
+			reset:     now.Add(2 * time.Second),
+			want:      2 * time.Second,
+		},
+		"plenty remaining": {
+			rateLimit: 40,
+			remaining: 30,
+			reset:     now.Add(time.Second),
+			lastStart: now,
+			want:      0,
+		},
+		"running low": {
+			rateLimit: 40,
+			remaining: 3,
+			reset:     now.Add(4 * time.Second),
+			lastStart: now,
+			want:      time.Second,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			l := &limiter{
+				rateLimit: testCase.rateLimit,
+				remaining: testCase.remaining,
+				reset:     testCase.reset,
+				lastStart: testCase.lastStart,
+			}
+			if got := l.paceDelay(now); got != testCase.want {
+				t.Errorf("got %s, want %s", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestLimiterTransportRespectsDeadline(t *testing.T) {
+	t.Parallel()
+
+	l := &limiter{
+		probed:    true,
+		rateLimit: 40,
+		remaining: 0,
+		reset:     time.Now().Add(time.Minute),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := l.acquire(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("waited %s, want an immediate error", elapsed)
+	}
+}
+
+func TestLimiterTransportUpdatesRateLimitWindow(t *testing.T) {
+	t.Parallel()
+
+	reset := time.Now().Add(time.Minute).Truncate(time.Second)
+	l := &limiter{}
+	rt := &limiterTransport{Delegate: &concurrencyRecorder{header: header(
+		"X-Sentry-Rate-Limit-Limit", "40",
+		headerRateRemaining, "7",
+		"X-Sentry-Rate-Limit-Reset", strconv.FormatInt(reset.Unix(), 10),
+	)}, limiter: l}
+	runConcurrently(t, rt, 1)
+
+	if l.rateLimit != 40 || l.remaining != 7 || !l.reset.Equal(reset) {
+		t.Errorf("got limit %d, remaining %d, reset %s", l.rateLimit, l.remaining, l.reset)
+	}
+}
+
+func TestLimiterTransportShared(t *testing.T) {
+	t.Parallel()
+
+	// The clients of the muxed providers share the concurrency limit
+	recorder := &concurrencyRecorder{header: header(headerRateConcurrentLimit, "3"), delay: 20 * time.Millisecond}
+	key := credentialsKey("https://sentry.example.com/api/", "TestLimiterTransportShared", nil)
+	rt1 := &limiterTransport{Delegate: recorder, limiter: sharedLimiter(key, 0)}
+	rt2 := &limiterTransport{Delegate: recorder, limiter: sharedLimiter(key, 0)}
+
+	runConcurrently(t, rt1, 1)
+	var wg sync.WaitGroup
+	for _, rt := range []http.RoundTripper{rt1, rt2} {
+		wg.Add(1)
+		go func(rt http.RoundTripper) {
+			defer wg.Done()
+			runConcurrently(t, rt, 10)
+		}(rt)
+	}
+	wg.Wait()
+
+	if got := recorder.peak.Load(); got != 3 {
+		t.Errorf("got peak concurrency %d, want 3", got)
+	}
+
+	other := credentialsKey("https://sentry.example.com/api/", "TestLimiterTransportShared-other", nil)
+	if sharedLimiter(other, 0) == rt1.limiter {
+		t.Error("clients of different tokens share a limiter")
+	}
+}
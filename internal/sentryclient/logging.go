@@ -0,0 +1,216 @@
+package sentryclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "[REDACTED]"
+
+// redactedHeaders are the request and response headers that are never logged.
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// redactedFieldSuffixes are the endings of the JSON fields that are never
+// logged, compared in lower case without underscores. They cover client key
+// (DSN) secrets, symbol source passwords and keys, integration keys and
+// tokens among others. Fields named just "key", such as tag keys, are logged.
+var redactedFieldSuffixes = []string{
+	"apikey",
+	"integrationkey",
+	"password",
+	"privatekey",
+	"secret",
+	"secretkey",
+	"token",
+}
+
+// rateLimitHeaders are the response headers logged to help diagnose rate
+// limiting.
+var rateLimitHeaders = map[string]string{
+	"X-Sentry-Rate-Limit-Limit":               "sentry.rate_limit.limit",
+	"X-Sentry-Rate-Limit-Remaining":           "sentry.rate_limit.remaining",
+	"X-Sentry-Rate-Limit-Reset":               "sentry.rate_limit.reset",
+	"X-Sentry-Rate-Limit-ConcurrentLimit":     "sentry.rate_limit.concurrent_limit",
+	"X-Sentry-Rate-Limit-ConcurrentRemaining": "sentry.rate_limit.concurrent_remaining",
+}
+
+type attemptContextKey struct{}
+
+// setAttempt is a retryablehttp.RequestLogHook that records the attempt number
+// in the request context for loggingTransport.
+func setAttempt(_ retryablehttp.Logger, req *http.Request, retryNumber int) {
+	*req = *req.WithContext(context.WithValue(req.Context(), attemptContextKey{}, retryNumber+1))
+}
+
+// loggingTransport logs each request attempt through tflog: a summary at
+// DEBUG and the headers and bodies at TRACE, with secrets redacted.
+//
+// Requests of clients bound to a Terraform operation (see WithContext) are
+// logged through its context, with the fields of the RPC. go-sentry does not
+// attach the caller's context to the requests of other clients, so they are
+// logged through the context the client was created with.
+type loggingTransport struct {
+	Delegate http.RoundTripper
+
+	ctx context.Context
+	// trace is whether TRACE logs are written, see traceLogsEnabled. Bodies
+	// are only buffered for logging if set.
+	trace bool
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if _, ok := ctx.Value(boundContextKey{}).(bool); !ok {
+		ctx = t.ctx
+	}
+
+	fields := map[string]interface{}{
+		"http.method": req.Method,
+		"http.url":    req.URL.String(),
+	}
+	if attempt, ok := req.Context().Value(attemptContextKey{}).(int); ok {
+		fields["http.attempt"] = attempt
+	}
+
+	var traceFields map[string]interface{}
+	if t.trace {
+		traceFields = map[string]interface{}{
+			"http.request.headers": redactHeaders(req.Header),
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			traceFields["http.request.body"] = redactBody(body)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.Delegate.RoundTrip(req)
+	fields["http.duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Sentry API request failed", fields)
+		return resp, err
+	}
+
+	fields["http.status_code"] = resp.StatusCode
+	for header, field := range rateLimitHeaders {
+		if v := resp.Header.Get(header); v != "" {
+			fields[field] = v
+		}
+	}
+	tflog.Debug(ctx, "Sentry API request", fields)
+
+	if !t.trace {
+		return resp, nil
+	}
+
+	traceFields["http.response.headers"] = redactHeaders(resp.Header)
+	if resp.Body != nil {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		traceFields["http.response.body"] = redactBody(body)
+	}
+	for k, v := range fields {
+		traceFields[k] = v
+	}
+	tflog.Trace(ctx, "Sentry API request details", traceFields)
+
+	return resp, nil
+}
+
+// traceLogsEnabled reports whether the TRACE logs of the provider are written,
+// from the environment variables Terraform and terraform-plugin-go read the
+// log levels from. Provider logs are filtered by Terraform, so the level is
+// not known to tflog.
+func traceLogsEnabled() bool {
+	for _, name := range []string{"TF_LOG_PROVIDER_SENTRY", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToUpper(strings.TrimSpace(v))
+			return v == "TRACE" || v == "JSON"
+		}
+	}
+	return false
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k := range h {
+		out[k] = h.Get(k)
+	}
+	for _, k := range redactedHeaders {
+		if _, ok := out[http.CanonicalHeaderKey(k)]; ok {
+			out[http.CanonicalHeaderKey(k)] = redacted
+		}
+	}
+	return out
+}
+
+// redactBody returns the body with the values of secret JSON fields
+// redacted. Bodies that are not JSON are returned as is.
+func redactBody(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(out)
+}
+
+// isRedactedField reports whether the JSON field name holds a secret.
+func isRedactedField(name string) bool {
+	name = strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	for _, suffix := range redactedFieldSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if isRedactedField(k) && child != nil {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(child)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
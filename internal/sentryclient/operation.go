@@ -0,0 +1,101 @@
+package sentryclient
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// Terraform operations requests are made for.
+const (
+	OperationPlan   = "plan"
+	OperationCreate = "create"
+	OperationRead   = "read"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+	OperationImport = "import"
+)
+
+// Operation describes the Terraform operation requests are made for.
+type Operation struct {
+	// TypeName is the resource or data source type, e.g. `sentry_project`.
+	TypeName string
+	// Name is one of the Operation* constants.
+	Name string
+
+	// MissingScopes are the scopes required by the resource that the token
+	// does not have, if the token scopes are checked.
+	MissingScopes []string
+	// TokenType is the type of the token, if the token scopes are checked.
+	TokenType TokenType
+}
+
+type operationContextKey struct{}
+
+// boundContextKey marks the contexts of the requests of clients returned by
+// WithContext.
+type boundContextKey struct{}
+
+// ContextWithOperation returns a copy of ctx carrying op.
+func ContextWithOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, op)
+}
+
+// OperationFromContext returns the Operation carried by ctx, if any.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationContextKey{}).(Operation)
+	return op, ok
+}
+
+// httpClients are the HTTP clients of the clients created by Config.Client.
+var httpClients sync.Map // map[*sentry.Client]*http.Client
+
+// WithContext returns a client sharing the connection, retries and limits of
+// client, whose requests carry ctx and the Operation in it. go-sentry does not
+// attach the context passed to its methods to requests, so resources use it
+// to attribute their requests to a Terraform operation.
+//
+// client must have been created by Config.Client, otherwise it is returned as
+// is.
+func WithContext(ctx context.Context, client *sentry.Client) *sentry.Client {
+	v, ok := httpClients.Load(client)
+	if !ok {
+		return client
+	}
+	httpClient := v.(*http.Client)
+
+	cl := sentry.NewClient(&http.Client{
+		Transport: &operationTransport{
+			Delegate: httpClient.Transport,
+			ctx:      ctx,
+		},
+	})
+	cl.BaseURL = client.BaseURL
+	cl.UserAgent = client.UserAgent
+	return cl
+}
+
+// operationTransport attaches ctx to requests.
+type operationTransport struct {
+	Delegate http.RoundTripper
+
+	ctx context.Context
+}
+
+func (t *operationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.WithContext(context.WithValue(t.ctx, boundContextKey{}, true))
+
+	resp, err := t.Delegate.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		if op, ok := OperationFromContext(t.ctx); ok && len(op.MissingScopes) > 0 {
+			explainForbidden(resp, op)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,107 @@
+package sentryclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// Project is a project with the settings that go-sentry does not decode.
+type Project struct {
+	sentry.Project
+
+	StoreCrashReports *int                `json:"storeCrashReports"`
+	HighlightTags     []string            `json:"highlightTags"`
+	HighlightContext  map[string][]string `json:"highlightContext"`
+	RelayPiiConfig    *string             `json:"relayPiiConfig"`
+}
+
+// UpdateProjectParams are the parameters of UpdateProject. Unlike the ones of
+// go-sentry, the settings are only sent when they are not nil, so that they
+// can be set to their zero values.
+type UpdateProjectParams struct {
+	sentry.UpdateProjectParams
+
+	SubjectPrefix       *string              `json:"subjectPrefix,omitempty"`
+	SubjectTemplate     *string              `json:"subjectTemplate,omitempty"`
+	AllowedDomains      *[]string            `json:"allowedDomains,omitempty"`
+	ScrapeJavaScript    *bool                `json:"scrapeJavaScript,omitempty"`
+	SecurityToken       *string              `json:"securityToken,omitempty"`
+	SecurityTokenHeader *string              `json:"securityTokenHeader,omitempty"`
+	VerifySSL           *bool                `json:"verifySSL,omitempty"`
+	StoreCrashReports   *int                 `json:"storeCrashReports,omitempty"`
+	HighlightTags       *[]string            `json:"highlightTags,omitempty"`
+	HighlightContext    *map[string][]string `json:"highlightContext,omitempty"`
+	RelayPiiConfig      *string              `json:"relayPiiConfig,omitempty"`
+
+	DataScrubber         *bool     `json:"dataScrubber,omitempty"`
+	DataScrubberDefaults *bool     `json:"dataScrubberDefaults,omitempty"`
+	ScrubIPAddresses     *bool     `json:"scrubIPAddresses,omitempty"`
+	SensitiveFields      *[]string `json:"sensitiveFields,omitempty"`
+	SafeFields           *[]string `json:"safeFields,omitempty"`
+
+	FingerprintingRules  *string `json:"fingerprintingRules,omitempty"`
+	GroupingEnhancements *string `json:"groupingEnhancements,omitempty"`
+}
+
+func projectURL(org string, slug string) string {
+	return fmt.Sprintf("0/projects/%v/%v/", url.PathEscape(org), url.PathEscape(slug))
+}
+
+// GetProject reads a project with all of its settings.
+func GetProject(ctx context.Context, client *sentry.Client, org string, slug string) (*Project, *sentry.Response, error) {
+	req, err := client.NewRequest(http.MethodGet, projectURL(org, slug), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project := new(Project)
+	resp, err := client.Do(ctx, req, project)
+	if err != nil {
+		return nil, resp, err
+	}
+	return project, resp, nil
+}
+
+// UpdateProject updates the settings of a project set in params.
+func UpdateProject(ctx context.Context, client *sentry.Client, org string, slug string, params *UpdateProjectParams) (*Project, *sentry.Response, error) {
+	req, err := client.NewRequest(http.MethodPut, projectURL(org, slug), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project := new(Project)
+	resp, err := client.Do(ctx, req, project)
+	if err != nil {
+		return nil, resp, err
+	}
+	return project, resp, nil
+}
+
+// UpdateProjectOwnershipParams are the parameters of UpdateProjectOwnership.
+// Unlike the ones of go-sentry, the rules can be cleared.
+type UpdateProjectOwnershipParams struct {
+	Raw                *string `json:"raw,omitempty"`
+	FallThrough        *bool   `json:"fallthrough,omitempty"`
+	AutoAssignment     *string `json:"autoAssignment,omitempty"`
+	CodeownersAutoSync *bool   `json:"codeownersAutoSync,omitempty"`
+}
+
+// UpdateProjectOwnership updates the ownership settings of a project set in
+// params.
+func UpdateProjectOwnership(ctx context.Context, client *sentry.Client, org string, slug string, params *UpdateProjectOwnershipParams) (*sentry.ProjectOwnership, *sentry.Response, error) {
+	req, err := client.NewRequest(http.MethodPut, projectURL(org, slug)+"ownership/", params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ownership := new(sentry.ProjectOwnership)
+	resp, err := client.Do(ctx, req, ownership)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ownership, resp, nil
+}
@@ -0,0 +1,112 @@
+package sentryclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func TestUpdateProject(t *testing.T) {
+	t.Parallel()
+
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/0/projects/my-org/my-project/" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug":"my-project","allowedDomains":[],"scrapeJavaScript":false,"storeCrashReports":null,"highlightTags":["release"],"highlightContext":{"trace":["trace_id"]},"relayPiiConfig":"{}"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Config{BaseURL: srv.URL + "/api/"}
+	client, err := c.Client(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	project, _, err := UpdateProject(context.Background(), client, "my-org", "my-project", &UpdateProjectParams{
+		UpdateProjectParams: sentry.UpdateProjectParams{Name: "My Project"},
+		AllowedDomains:      &[]string{},
+		ScrapeJavaScript:    sentry.Bool(false),
+		HighlightTags:       &[]string{"release"},
+		HighlightContext:    &map[string][]string{"trace": {"trace_id"}},
+		RelayPiiConfig:      sentry.String("{}"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Zero values are sent, unset settings are not
+	want := map[string]interface{}{
+		"name":             "My Project",
+		"allowedDomains":   []interface{}{},
+		"scrapeJavaScript": false,
+		"highlightTags":    []interface{}{"release"},
+		"highlightContext": map[string]interface{}{"trace": []interface{}{"trace_id"}},
+		"relayPiiConfig":   "{}",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got body %v, want %v", got, want)
+	}
+
+	if project.Slug != "my-project" || project.StoreCrashReports != nil || !reflect.DeepEqual(project.HighlightTags, []string{"release"}) ||
+		!reflect.DeepEqual(project.HighlightContext, map[string][]string{"trace": {"trace_id"}}) || project.RelayPiiConfig == nil || *project.RelayPiiConfig != "{}" {
+		t.Errorf("got project %+v", project)
+	}
+}
+
+func TestUpdateProjectOwnership(t *testing.T) {
+	t.Parallel()
+
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/0/projects/my-org/my-project/ownership/" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("unexpected body: %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"raw":"","fallthrough":false,"autoAssignment":"Turn off Auto-Assignment","codeownersAutoSync":true}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Config{BaseURL: srv.URL + "/api/"}
+	client, err := c.Client(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ownership, _, err := UpdateProjectOwnership(context.Background(), client, "my-org", "my-project", &UpdateProjectOwnershipParams{
+		Raw:         sentry.String(""),
+		FallThrough: sentry.Bool(false),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Cleared rules are sent, unset settings are not
+	want := map[string]interface{}{
+		"raw":         "",
+		"fallthrough": false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got body %v, want %v", got, want)
+	}
+
+	if ownership.Raw != "" || ownership.FallThrough || ownership.AutoAssignment != "Turn off Auto-Assignment" {
+		t.Errorf("got ownership %+v", ownership)
+	}
+}
@@ -0,0 +1,50 @@
+package sentryclient
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ReadOnlyError is returned for the requests that would change Sentry when
+// the client is read-only.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+
+	// Operation is the operation the request was made for, if known.
+	Operation *Operation
+}
+
+func (e *ReadOnlyError) Error() string {
+	msg := fmt.Sprintf("the provider is in read-only mode and refused to send %s %s", e.Method, e.Path)
+	if e.Operation != nil {
+		msg += fmt.Sprintf(" to %s %s", e.Operation.Name, e.Operation.TypeName)
+	}
+	return msg + ". Unset read_only and SENTRY_READ_ONLY to make changes."
+}
+
+// readOnlyTransport rejects the requests that would change Sentry without
+// sending them.
+type readOnlyTransport struct {
+	Delegate http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return t.Delegate.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	err := &ReadOnlyError{
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+	if op, ok := OperationFromContext(req.Context()); ok {
+		err.Operation = &op
+	}
+	return nil, err
+}
@@ -0,0 +1,58 @@
+package sentryclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConfigReadOnly(t *testing.T) {
+	t.Parallel()
+
+	var writes atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Config{BaseURL: srv.URL + "/api/", ReadOnly: true}
+	client, err := c.Client(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, _, err := client.ProjectKeys.List(context.Background(), "my-org", "my-project", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := ContextWithOperation(context.Background(), Operation{
+		TypeName: "sentry_key",
+		Name:     OperationCreate,
+	})
+	_, _, err = WithContext(ctx, client).ProjectKeys.Create(ctx, "my-org", "my-project", nil)
+
+	var readOnlyErr *ReadOnlyError
+	if !errors.As(err, &readOnlyErr) {
+		t.Fatalf("got %v, want a *ReadOnlyError", err)
+	}
+	want := "refused to send POST /api/0/projects/my-org/my-project/keys/ to create sentry_key"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("got %q, want it to contain %q", err, want)
+	}
+
+	if _, err := client.ProjectKeys.Delete(context.Background(), "my-org", "my-project", "abc"); !errors.As(err, &readOnlyErr) {
+		t.Errorf("got %v, want a *ReadOnlyError", err)
+	}
+
+	if got := writes.Load(); got != 0 {
+		t.Errorf("got %d writes sent to the server, want 0", got)
+	}
+}
@@ -0,0 +1,155 @@
+package sentryclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+// organizationScopedPaths are the first API path segments followed by an
+// organization slug.
+var organizationScopedPaths = map[string]struct{}{
+	"organizations": {},
+	"projects":      {},
+	"teams":         {},
+}
+
+// organizationFromPath returns the organization slug an API path is scoped to,
+// or an empty string.
+func organizationFromPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/api/0/")
+	if !ok {
+		return ""
+	}
+
+	segments := strings.SplitN(rest, "/", 3)
+	if len(segments) < 2 {
+		return ""
+	}
+	if _, ok := organizationScopedPaths[segments[0]]; !ok {
+		return ""
+	}
+	return segments[1]
+}
+
+// regionTransport routes the requests for an organization to the API host of
+// the region the organization is hosted in, as reported by the organization
+// details endpoint. Regions are resolved once per organization.
+//
+// Requests are sent to their original host if the region cannot be resolved,
+// so errors such as a missing organization are reported by the request itself.
+// Such organizations are not looked up again either, as a token that cannot
+// read an organization would otherwise double the requests for it.
+type regionTransport struct {
+	Delegate http.RoundTripper
+
+	ctx context.Context
+
+	group   singleflight.Group
+	mu      sync.Mutex
+	regions map[string]*url.URL
+}
+
+func (t *regionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	organization := organizationFromPath(req.URL.Path)
+	if organization == "" {
+		return t.Delegate.RoundTrip(req)
+	}
+
+	region := t.region(req, organization)
+	if region == nil || region.Host == req.URL.Host {
+		return t.Delegate.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.URL.Scheme = region.Scheme
+	req.URL.Host = region.Host
+	req.Host = ""
+	return t.Delegate.RoundTrip(req)
+}
+
+// region returns the region URL of an organization, or nil if it is unknown.
+func (t *regionTransport) region(req *http.Request, organization string) *url.URL {
+	t.mu.Lock()
+	region, ok := t.regions[organization]
+	t.mu.Unlock()
+	if ok {
+		return region
+	}
+
+	v, _, _ := t.group.Do(organization, func() (interface{}, error) {
+		region, ok := t.resolve(req, organization)
+		if ok {
+			t.mu.Lock()
+			if t.regions == nil {
+				t.regions = make(map[string]*url.URL)
+			}
+			t.regions[organization] = region
+			t.mu.Unlock()
+		}
+		return region, nil
+	})
+	return v.(*url.URL)
+}
+
+// resolve reads the region URL of an organization, or nil if Sentry does not
+// report one, for example because the token cannot read the organization. It
+// reports false if no response was received, in which case the result is not
+// cached.
+func (t *regionTransport) resolve(req *http.Request, organization string) (*url.URL, bool) {
+	u := &url.URL{
+		Scheme: req.URL.Scheme,
+		Host:   req.URL.Host,
+		Path:   "/api/0/organizations/" + organization + "/",
+	}
+	lookupReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, false
+	}
+	lookupReq.Header.Set("Accept", "application/json")
+	lookupReq.Header.Set("User-Agent", req.Header.Get("User-Agent"))
+
+	resp, err := t.Delegate.RoundTrip(lookupReq)
+	if err != nil {
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		tflog.Debug(t.ctx, "Unable to resolve Sentry organization region", map[string]interface{}{
+			"sentry.organization": organization,
+			"http.status_code":    resp.StatusCode,
+		})
+		return nil, true
+	}
+
+	var body struct {
+		Links struct {
+			RegionURL string `json:"regionUrl"`
+		} `json:"links"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, true
+	}
+
+	// Organizations outside of a region are served by the original host.
+	if body.Links.RegionURL == "" {
+		return nil, true
+	}
+	region, err := url.Parse(body.Links.RegionURL)
+	if err != nil || region.Host == "" {
+		return nil, true
+	}
+
+	tflog.Debug(t.ctx, "Resolved Sentry organization region", map[string]interface{}{
+		"sentry.organization": organization,
+		"sentry.region_url":   region.String(),
+	})
+	return region, true
+}
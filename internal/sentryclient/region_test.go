@@ -0,0 +1,109 @@
+package sentryclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOrganizationFromPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]string{
+		"/api/0/organizations/":                       "",
+		"/api/0/organizations/my-org/":                "my-org",
+		"/api/0/organizations/my-org/members/":        "my-org",
+		"/api/0/projects/my-org/my-project/keys/":     "my-org",
+		"/api/0/teams/my-org/my-team/":                "my-org",
+		"/api/0/projects/":                            "",
+		"/api/0/users/me/":                            "",
+		"/sentry/api/0/organizations/my-org/":         "",
+		"/api/0/sentry-app-installations/my-install/": "",
+	}
+
+	for path, want := range testCases {
+		path, want := path, want
+
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+
+			if got := organizationFromPath(path); got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRegionTransport(t *testing.T) {
+	t.Parallel()
+
+	var regionRequests atomic.Int32
+	region := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		regionRequests.Add(1)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(region.Close)
+
+	var lookups, controlRequests atomic.Int32
+	control := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/0/organizations/eu-org/":
+			lookups.Add(1)
+			fmt.Fprintf(w, `{"slug":"eu-org","links":{"organizationUrl":"https://eu-org.sentry.io","regionUrl":%q}}`, region.URL)
+		case "/api/0/organizations/legacy-org/":
+			lookups.Add(1)
+			_, _ = w.Write([]byte(`{"slug":"legacy-org"}`))
+		case "/api/0/organizations/missing-org/":
+			lookups.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/0/organizations/forbidden-org/":
+			lookups.Add(1)
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			controlRequests.Add(1)
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(control.Close)
+
+	rt := &regionTransport{Delegate: http.DefaultTransport, ctx: context.Background()}
+	do := func(path string) {
+		t.Helper()
+
+		req, _ := http.NewRequest(http.MethodGet, control.URL+path, nil)
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		resp.Body.Close()
+	}
+
+	do("/api/0/projects/eu-org/my-project/keys/")
+	do("/api/0/organizations/eu-org/members/")
+	if got := regionRequests.Load(); got != 2 {
+		t.Errorf("got %d requests to the region, want 2", got)
+	}
+	if got := lookups.Load(); got != 1 {
+		t.Errorf("got %d lookups, want 1", got)
+	}
+
+	// Organizations without a region, failed lookups and requests that are not
+	// scoped to an organization stay on the original host. Failed lookups are
+	// not repeated either.
+	do("/api/0/teams/legacy-org/my-team/members/")
+	do("/api/0/teams/legacy-org/my-team/members/")
+	do("/api/0/organizations/missing-org/projects/")
+	do("/api/0/organizations/missing-org/projects/")
+	do("/api/0/projects/forbidden-org/my-project/")
+	do("/api/0/projects/forbidden-org/my-project/")
+	do("/api/0/organizations/")
+	if got := controlRequests.Load(); got != 7 {
+		t.Errorf("got %d requests to the original host, want 7", got)
+	}
+	if got := lookups.Load(); got != 4 {
+		t.Errorf("got %d lookups, want 4", got)
+	}
+}
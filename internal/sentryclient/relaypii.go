@@ -0,0 +1,155 @@
+package sentryclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// RelayPiiConfig is the document of the advanced data scrubbing rules of a
+// project or an organization. The rules are kept as they are, so that rules
+// managed elsewhere survive a read-modify-write.
+type RelayPiiConfig struct {
+	Rules        map[string]json.RawMessage `json:"rules"`
+	Applications map[string][]string        `json:"applications"`
+	Vars         json.RawMessage            `json:"vars,omitempty"`
+}
+
+// RelayPiiRule is a rule of a RelayPiiConfig as created by the Sentry UI.
+type RelayPiiRule struct {
+	Type      string            `json:"type"`
+	Pattern   string            `json:"pattern,omitempty"`
+	Redaction RelayPiiRedaction `json:"redaction"`
+}
+
+type RelayPiiRedaction struct {
+	Method string `json:"method"`
+	Text   string `json:"text,omitempty"`
+}
+
+// ParseRelayPiiConfig parses the relayPiiConfig of a project or an
+// organization, which is empty if no rules were ever set.
+func ParseRelayPiiConfig(s string) (*RelayPiiConfig, error) {
+	config := &RelayPiiConfig{}
+	if s != "" {
+		if err := json.Unmarshal([]byte(s), config); err != nil {
+			return nil, fmt.Errorf("unable to parse the data scrubbing rules: %w", err)
+		}
+	}
+	if config.Rules == nil {
+		config.Rules = map[string]json.RawMessage{}
+	}
+	if config.Applications == nil {
+		config.Applications = map[string][]string{}
+	}
+	return config, nil
+}
+
+func (c *RelayPiiConfig) String() string {
+	b, _ := json.Marshal(c)
+	return string(b)
+}
+
+// Rule decodes the rule with the given key.
+func (c *RelayPiiConfig) Rule(key string) (*RelayPiiRule, bool) {
+	raw, ok := c.Rules[key]
+	if !ok {
+		return nil, false
+	}
+
+	var rule RelayPiiRule
+	if err := json.Unmarshal(raw, &rule); err != nil {
+		return nil, false
+	}
+	return &rule, true
+}
+
+// Source returns the first selector the rule with the given key applies to.
+func (c *RelayPiiConfig) Source(key string) (string, bool) {
+	selectors := make([]string, 0, len(c.Applications))
+	for selector := range c.Applications {
+		selectors = append(selectors, selector)
+	}
+	sort.Strings(selectors)
+
+	for _, selector := range selectors {
+		if slices.Contains(c.Applications[selector], key) {
+			return selector, true
+		}
+	}
+	return "", false
+}
+
+// SetRule sets the rule with the given key and applies it to source only.
+func (c *RelayPiiConfig) SetRule(key string, rule RelayPiiRule, source string) error {
+	raw, err := json.Marshal(rule)
+	if err != nil {
+		return err
+	}
+
+	c.RemoveRule(key)
+	c.Rules[key] = raw
+	c.Applications[source] = append(c.Applications[source], key)
+	return nil
+}
+
+// RemoveRule removes the rule with the given key and its applications.
+func (c *RelayPiiConfig) RemoveRule(key string) {
+	delete(c.Rules, key)
+	for selector, keys := range c.Applications {
+		keys = slices.DeleteFunc(keys, func(k string) bool { return k == key })
+		if len(keys) == 0 {
+			delete(c.Applications, selector)
+		} else {
+			c.Applications[selector] = keys
+		}
+	}
+}
+
+// GetOrganizationRelayPiiConfig reads the data scrubbing rules of an
+// organization.
+func GetOrganizationRelayPiiConfig(ctx context.Context, client *sentry.Client, org string) (*RelayPiiConfig, error) {
+	organization, _, err := client.Organizations.Get(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRelayPiiConfig(sentry.StringValue(organization.RelayPiiConfig))
+}
+
+// UpdateOrganizationRelayPiiConfig replaces the data scrubbing rules of an
+// organization.
+func UpdateOrganizationRelayPiiConfig(ctx context.Context, client *sentry.Client, org string, config *RelayPiiConfig) error {
+	params := map[string]string{
+		"relayPiiConfig": config.String(),
+	}
+	req, err := client.NewRequest(http.MethodPut, fmt.Sprintf("0/organizations/%v/", url.PathEscape(org)), params)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+// GetProjectRelayPiiConfig reads the data scrubbing rules of a project.
+func GetProjectRelayPiiConfig(ctx context.Context, client *sentry.Client, org string, slug string) (*RelayPiiConfig, error) {
+	project, _, err := GetProject(ctx, client, org, slug)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRelayPiiConfig(sentry.StringValue(project.RelayPiiConfig))
+}
+
+// UpdateProjectRelayPiiConfig replaces the data scrubbing rules of a project.
+func UpdateProjectRelayPiiConfig(ctx context.Context, client *sentry.Client, org string, slug string, config *RelayPiiConfig) error {
+	_, _, err := UpdateProject(ctx, client, org, slug, &UpdateProjectParams{
+		RelayPiiConfig: sentry.String(config.String()),
+	})
+	return err
+}
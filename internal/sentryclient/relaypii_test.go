@@ -0,0 +1,79 @@
+package sentryclient
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRelayPiiConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := ParseRelayPiiConfig(`{
+		"rules": {
+			"0": {"type": "multiple", "rules": ["@ip", "@email"], "hideRule": false, "redaction": {"method": "remove"}}
+		},
+		"applications": {"$string": ["0"]},
+		"vars": {"hashKey": "abc"}
+	}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := config.SetRule("tf", RelayPiiRule{Type: "pattern", Pattern: "[0-9]+", Redaction: RelayPiiRedaction{Method: "mask"}}, "$string"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := config.SetRule("tf", RelayPiiRule{Type: "password", Redaction: RelayPiiRedaction{Method: "replace", Text: "[redacted]"}}, "extra.password"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	rule, ok := config.Rule("tf")
+	if !ok || rule.Type != "password" || rule.Redaction.Text != "[redacted]" {
+		t.Errorf("got rule %+v", rule)
+	}
+	if source, ok := config.Source("tf"); !ok || source != "extra.password" {
+		t.Errorf("got source %q", source)
+	}
+
+	// Rules managed elsewhere are kept as they are
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(config.String()), &got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := map[string]interface{}{
+		"rules": map[string]interface{}{
+			"0":  map[string]interface{}{"type": "multiple", "rules": []interface{}{"@ip", "@email"}, "hideRule": false, "redaction": map[string]interface{}{"method": "remove"}},
+			"tf": map[string]interface{}{"type": "password", "redaction": map[string]interface{}{"method": "replace", "text": "[redacted]"}},
+		},
+		"applications": map[string]interface{}{
+			"$string":        []interface{}{"0"},
+			"extra.password": []interface{}{"tf"},
+		},
+		"vars": map[string]interface{}{"hashKey": "abc"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	config.RemoveRule("tf")
+	if _, ok := config.Rules["tf"]; ok {
+		t.Error("rule was not removed")
+	}
+	if _, ok := config.Applications["extra.password"]; ok {
+		t.Error("empty application was not removed")
+	}
+}
+
+func TestParseRelayPiiConfigEmpty(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "null", "{}"} {
+		config, err := ParseRelayPiiConfig(s)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", s, err)
+		}
+		if got := config.String(); got != `{"rules":{},"applications":{}}` {
+			t.Errorf("%q: got %s", s, got)
+		}
+	}
+}
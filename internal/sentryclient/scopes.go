@@ -0,0 +1,162 @@
+package sentryclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+// TokenType is the type of an authentication token.
+type TokenType string
+
+const (
+	TokenTypeUnknown             TokenType = ""
+	TokenTypeUser                TokenType = "user"
+	TokenTypeOrgAuthToken        TokenType = "org_auth_token"
+	TokenTypeInternalIntegration TokenType = "internal_integration"
+)
+
+func (t TokenType) String() string {
+	switch t {
+	case TokenTypeUser:
+		return "user auth token"
+	case TokenTypeOrgAuthToken:
+		return "organization auth token"
+	case TokenTypeInternalIntegration:
+		return "internal integration token"
+	default:
+		return "token"
+	}
+}
+
+// TokenInfo describes the token used by a client.
+type TokenInfo struct {
+	Type   TokenType
+	Scopes []string
+}
+
+// IntrospectToken reads the type and scopes of the token used by client.
+func (c *Config) IntrospectToken(ctx context.Context, client *sentry.Client) (*TokenInfo, error) {
+	req, err := client.NewRequest(http.MethodGet, "0/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var body struct {
+		Auth *struct {
+			Scopes []string `json:"scopes"`
+		} `json:"auth"`
+		User *struct {
+			Email string `json:"email"`
+		} `json:"user"`
+	}
+	if _, err := client.Do(ctx, req, &body); err != nil {
+		return nil, err
+	}
+	if body.Auth == nil {
+		return nil, fmt.Errorf("the token was not accepted by Sentry")
+	}
+
+	info := &TokenInfo{Scopes: body.Auth.Scopes}
+
+	token := c.Token
+	if c.TokenSource != nil {
+		if t, err := c.TokenSource.Token(); err == nil {
+			token = t.AccessToken
+		}
+	}
+	switch {
+	case strings.HasPrefix(token, "sntrys_"):
+		info.Type = TokenTypeOrgAuthToken
+	case strings.HasPrefix(token, "sntryu_"):
+		info.Type = TokenTypeUser
+	case body.User != nil && strings.HasSuffix(body.User.Email, "@proxy-user.sentry.io"):
+		// Internal integrations act through a proxy user.
+		info.Type = TokenTypeInternalIntegration
+	case body.User != nil:
+		info.Type = TokenTypeUser
+	}
+
+	return info, nil
+}
+
+// scopeLevels orders the levels of the scopes of a kind, each level granting
+// the ones before it.
+var scopeLevels = []string{"read", "write", "admin"}
+
+// scopeGrantedBy are the other scopes that grant a scope, like Sentry does.
+var scopeGrantedBy = map[string][]string{
+	"alerts:read":      {"project:read", "project:write", "project:admin"},
+	"alerts:write":     {"project:write", "project:admin"},
+	"org:integrations": {"org:admin"},
+}
+
+// hasScope reports whether the granted scopes grant scope.
+func hasScope(granted []string, scope string) bool {
+	kind, level, _ := strings.Cut(scope, ":")
+	minLevel := slices.Index(scopeLevels, level)
+
+	for _, g := range granted {
+		if g == scope || slices.Contains(scopeGrantedBy[scope], g) {
+			return true
+		}
+
+		gKind, gLevel, _ := strings.Cut(g, ":")
+		if gKind == kind && minLevel != -1 && slices.Index(scopeLevels, gLevel) >= minLevel {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingScopes returns the scopes in required that the token does not have.
+func (t *TokenInfo) MissingScopes(required []string) []string {
+	var missing []string
+	for _, scope := range required {
+		if !hasScope(t.Scopes, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// MissingScopesMessage explains that the token is missing the scopes required
+// by a resource type.
+func MissingScopesMessage(typeName string, missing []string, tokenType TokenType) string {
+	return fmt.Sprintf(
+		"%s requires the %s scope(s), which the %s used by the provider does not have. Grant the scope(s) to the token, or use a token that has them.",
+		typeName, strings.Join(missing, ", "), tokenType,
+	)
+}
+
+// explainForbidden adds the scopes missing for the operation to the detail of
+// a 403 response.
+func explainForbidden(resp *http.Response, op Operation) {
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		return
+	}
+
+	message := MissingScopesMessage(op.TypeName, op.MissingScopes, op.TokenType)
+
+	var body struct {
+		Detail string `json:"detail"`
+	}
+	if err := json.Unmarshal(data, &body); err == nil && body.Detail != "" {
+		message = body.Detail + " " + message
+	}
+
+	data, _ = json.Marshal(map[string]string{"detail": message})
+	resp.Header.Set("Content-Type", "application/json")
+	resp.ContentLength = int64(len(data))
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+}
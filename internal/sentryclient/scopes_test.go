@@ -0,0 +1,171 @@
+package sentryclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTokenInfoMissingScopes(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		granted  []string
+		required []string
+		want     []string
+	}{
+		"exact": {
+			granted:  []string{"project:write"},
+			required: []string{"project:write"},
+		},
+		"higher level": {
+			granted:  []string{"team:admin"},
+			required: []string{"team:read", "team:write"},
+		},
+		"lower level": {
+			granted:  []string{"project:read"},
+			required: []string{"project:write"},
+			want:     []string{"project:write"},
+		},
+		"other kind": {
+			granted:  []string{"org:admin"},
+			required: []string{"project:read"},
+			want:     []string{"project:read"},
+		},
+		"alerts granted by project": {
+			granted:  []string{"project:write"},
+			required: []string{"alerts:write"},
+		},
+		"alerts not granted by project read": {
+			granted:  []string{"project:read"},
+			required: []string{"alerts:write"},
+			want:     []string{"alerts:write"},
+		},
+		"integrations granted by org admin": {
+			granted:  []string{"org:admin"},
+			required: []string{"org:integrations"},
+		},
+		"integrations not granted by org write": {
+			granted:  []string{"org:write"},
+			required: []string{"org:integrations"},
+			want:     []string{"org:integrations"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			info := &TokenInfo{Scopes: testCase.granted}
+			if got := info.MissingScopes(testCase.required); !slices.Equal(got, testCase.want) {
+				t.Errorf("got %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestConfigIntrospectToken(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		token string
+		body  string
+		want  TokenType
+	}{
+		"user auth token": {
+			token: "sntryu_abc",
+			body:  `{"version":"0","auth":{"scopes":["org:read"]},"user":{"email":"jane@example.com"}}`,
+			want:  TokenTypeUser,
+		},
+		"organization auth token": {
+			token: "sntrys_abc",
+			body:  `{"version":"0","auth":{"scopes":["org:ci"]},"user":null}`,
+			want:  TokenTypeOrgAuthToken,
+		},
+		"internal integration": {
+			token: "abc",
+			body:  `{"version":"0","auth":{"scopes":["org:read"]},"user":{"email":"my-integration-1a2b3c@proxy-user.sentry.io"}}`,
+			want:  TokenTypeInternalIntegration,
+		},
+		"legacy user token": {
+			token: "abc",
+			body:  `{"version":"0","auth":{"scopes":["org:read"]},"user":{"email":"jane@example.com"}}`,
+			want:  TokenTypeUser,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/0/" {
+					t.Errorf("unexpected request: %s", r.URL)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			t.Cleanup(srv.Close)
+
+			c := &Config{Token: testCase.token, BaseURL: srv.URL + "/api/"}
+			client, err := c.Client(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			info, err := c.IntrospectToken(context.Background(), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if info.Type != testCase.want {
+				t.Errorf("got type %q, want %q", info.Type, testCase.want)
+			}
+		})
+	}
+}
+
+func TestWithContextExplainsForbidden(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"detail":"You do not have permission to perform this action."}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := &Config{BaseURL: srv.URL + "/api/"}
+	client, err := c.Client(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ctx := ContextWithOperation(context.Background(), Operation{
+		TypeName:      "sentry_key",
+		Name:          OperationCreate,
+		MissingScopes: []string{"project:write"},
+		TokenType:     TokenTypeInternalIntegration,
+	})
+	_, _, err = WithContext(ctx, client).ProjectKeys.Create(ctx, "my-org", "my-project", nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	want := "You do not have permission to perform this action. sentry_key requires the project:write scope(s), which the internal integration token used by the provider does not have."
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("got %q, want it to contain %q", err, want)
+	}
+
+	// Requests without missing scopes are left as is
+	_, _, err = client.ProjectKeys.Create(ctx, "my-org", "my-project", nil)
+	if err == nil || strings.Contains(err.Error(), "requires") {
+		t.Errorf("got %v, want the original error", err)
+	}
+}
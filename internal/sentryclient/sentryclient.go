@@ -2,14 +2,28 @@ package sentryclient
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"net/http"
-	"sync"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/jianyuan/go-sentry/v2/sentry"
 	"golang.org/x/oauth2"
-	"golang.org/x/sync/semaphore"
+)
+
+// Default retry policy used when the corresponding Config field is unset.
+const (
+	DefaultMaxRetries       = 4
+	DefaultMinBackoff       = 1 * time.Second
+	DefaultMaxBackoff       = 30 * time.Second
+	DefaultMaxRateLimitWait = 1 * time.Minute
 )
 
 // Config is the configuration structure used to instantiate the Sentry
@@ -17,82 +31,300 @@ import (
 type Config struct {
 	UserAgent string
 	Token     string
-	BaseURL   string
+	// BaseURL is the Sentry API URL. If empty, sentry.io is used and the
+	// requests for each organization are routed to the API host of the region
+	// the organization is hosted in.
+	BaseURL string
+
+	// TokenSource, if set, is used instead of Token.
+	TokenSource oauth2.TokenSource
+
+	// MaxRetries is the maximum number of retries for a failed request.
+	// Defaults to DefaultMaxRetries if nil.
+	MaxRetries *int
+	// MinBackoff and MaxBackoff bound the wait between retries. Default to
+	// DefaultMinBackoff and DefaultMaxBackoff if zero.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RequestTimeout is the timeout for each request attempt. Zero means no
+	// timeout.
+	RequestTimeout time.Duration
+	// RetryStatusCodes are the response status codes to retry. If empty, 429
+	// and 5xx responses other than 501 are retried. Rate limited responses are
+	// always retried.
+	RetryStatusCodes []int
+	// MaxRateLimitWait caps the wait for a rate limit to reset. Defaults to
+	// DefaultMaxRateLimitWait if zero.
+	MaxRateLimitWait time.Duration
+
+	// CACert, ClientCert and ClientKey are PEM-encoded values or paths to
+	// PEM files. CACert is added to the system certificate pool.
+	CACert     string
+	ClientCert string
+	ClientKey  string
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+	// ProxyURL overrides the proxy sourced from the environment.
+	ProxyURL string
+
+	// MaxConcurrentRequests caps the number of concurrent requests. If zero,
+	// the concurrency limit reported by Sentry is used.
+	MaxConcurrentRequests int
+
+	// Cache, if set, is invalidated by every write made by the client.
+	Cache *Cache
+
+	// AuditLog, if set, records every request other than GET, HEAD and
+	// OPTIONS.
+	AuditLog *AuditLog
+
+	// ReadOnly rejects every request other than GET, HEAD and OPTIONS with a
+	// *ReadOnlyError, without sending it.
+	ReadOnly bool
+
+	// WrapTransport, if set, wraps the transport that sends each request
+	// attempt, for example to record or replay the requests in tests. Defaults
+	// to DefaultWrapTransport if nil.
+	WrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
+// DefaultWrapTransport is used by configurations that do not set
+// WrapTransport. Acceptance tests set it to record or replay the requests
+// made by the provider.
+var DefaultWrapTransport func(http.RoundTripper) http.RoundTripper
+
 // Client to connect to Sentry.
 func (c *Config) Client(ctx context.Context) (*sentry.Client, error) {
+	// TLS and proxy
+	transport, err := c.transport()
+	if err != nil {
+		return nil, err
+	}
+	var roundTripper http.RoundTripper = transport
+	if wrap := c.WrapTransport; wrap != nil {
+		roundTripper = wrap(roundTripper)
+	} else if DefaultWrapTransport != nil {
+		roundTripper = DefaultWrapTransport(roundTripper)
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{
+		Transport: &limiterTransport{
+			Delegate: &loggingTransport{
+				Delegate: roundTripper,
+				ctx:      ctx,
+				trace:    traceLogsEnabled(),
+			},
+			limiter: sharedLimiter(credentialsKey(c.BaseURL, c.Token, c.TokenSource), c.MaxConcurrentRequests),
+		},
+	})
+
 	// Authentication
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token})
+	ts := c.TokenSource
+	if ts == nil {
+		ts = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: c.Token})
+	}
 	oauth2HTTPClient := oauth2.NewClient(ctx, ts)
+	oauth2HTTPClient.Timeout = c.RequestTimeout
 
-	// Handle rate limit
+	// Handle retries and rate limit
 	retryClient := retryablehttp.NewClient()
 	retryClient.HTTPClient = oauth2HTTPClient
 	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
-	retryClient.Logger = nil // Disable DEBUG logs
-	retryClient.Backoff = func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
-		if rateLimitErr, ok := sentry.CheckResponse(resp).(*sentry.RateLimitError); ok {
-			return time.Until(rateLimitErr.Rate.Reset)
-		}
-		return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+	retryClient.Logger = nil // Disable DEBUG logs, loggingTransport logs each attempt
+	retryClient.RequestLogHook = setAttempt
+	retryClient.RetryMax = DefaultMaxRetries
+	if c.MaxRetries != nil {
+		retryClient.RetryMax = *c.MaxRetries
+	}
+	if c.MinBackoff > 0 {
+		retryClient.RetryWaitMin = c.MinBackoff
+	} else {
+		retryClient.RetryWaitMin = DefaultMinBackoff
+	}
+	if c.MaxBackoff > 0 {
+		retryClient.RetryWaitMax = c.MaxBackoff
+	} else {
+		retryClient.RetryWaitMax = DefaultMaxBackoff
 	}
+	retryClient.CheckRetry = c.checkRetry
+	retryClient.Backoff = c.backoff
 	retryHTTPClient := retryClient.StandardClient()
 
-	// Handle concurrency limit
-	semaphoreHTTPClient := &http.Client{
-		Transport: &semaphoreTransport{
+	// Invalidate cached lists once a write and its retries are done
+	if c.Cache != nil {
+		retryHTTPClient.Transport = &cacheInvalidatingTransport{
 			Delegate: retryHTTPClient.Transport,
-		},
+			Cache:    c.Cache,
+		}
 	}
 
 	// Initialize client
 	var cl *sentry.Client
-	var err error
 	if c.BaseURL == "" {
-		cl = sentry.NewClient(semaphoreHTTPClient)
+		retryHTTPClient.Transport = &regionTransport{
+			Delegate: retryHTTPClient.Transport,
+			ctx:      ctx,
+		}
+		cl = sentry.NewClient(retryHTTPClient)
 	} else {
-		cl, err = sentry.NewOnPremiseClient(c.BaseURL, semaphoreHTTPClient)
+		cl, err = sentry.NewOnPremiseClient(c.BaseURL, retryHTTPClient)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// Record writes once they and their retries are done
+	if c.AuditLog != nil {
+		retryHTTPClient.Transport = &auditTransport{
+			Delegate: retryHTTPClient.Transport,
+			AuditLog: c.AuditLog,
+		}
+	}
+
+	// Reject writes before they are audited, retried or routed
+	if c.ReadOnly {
+		retryHTTPClient.Transport = &readOnlyTransport{
+			Delegate: retryHTTPClient.Transport,
+		}
+	}
+
 	// Set user agent
 	cl.UserAgent = c.UserAgent
 
+	httpClients.Store(cl, retryHTTPClient)
+
 	return cl, nil
 }
 
-type semaphoreTransport struct {
-	Delegate http.RoundTripper
+func (c *Config) transport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CACert != "" {
+		caCert, err := readPEM(c.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
 
-	mu sync.RWMutex
-	w  *semaphore.Weighted
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("failed to parse CA certificate: no certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.ClientCert != "" || c.ClientKey != "" {
+		if c.ClientCert == "" || c.ClientKey == "" {
+			return nil, errors.New("client certificate and client key must be set together")
+		}
+
+		clientCert, err := readPEM(c.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		clientKey, err := readPEM(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
 }
 
-func (t *semaphoreTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	t.mu.RLock()
-	if t.w == nil {
-		t.mu.RUnlock()
-		t.mu.Lock()
-		resp, err := t.Delegate.RoundTrip(req)
-		if resp != nil {
-			rate := sentry.ParseRate(resp)
-			if rate.ConcurrentLimit > 0 {
-				t.w = semaphore.NewWeighted(int64(rate.ConcurrentLimit))
-			}
-		}
-		t.mu.Unlock()
-		return resp, err
-	}
-	t.mu.RUnlock()
-
-	ctx := req.Context()
-	if err := t.w.Acquire(ctx, 1); err != nil {
-		return nil, err
+// readPEM returns the value if it is PEM-encoded, otherwise it reads the file
+// at the given path.
+func readPEM(v string) ([]byte, error) {
+	if strings.Contains(v, "-----BEGIN") {
+		return []byte(v), nil
+	}
+	return os.ReadFile(v)
+}
+
+func (c *Config) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	retry, err := c.shouldRetry(ctx, resp, err)
+	if !retry || err != nil {
+		return retry, err
 	}
-	defer t.w.Release(1)
 
-	return t.Delegate.RoundTrip(req)
+	// Fail with the rate limit error rather than wait for the rate limit to
+	// reset past the deadline of the request.
+	if deadline, ok := ctx.Deadline(); ok && resp != nil {
+		if wait, ok := c.rateLimitWait(resp); ok && time.Now().Add(wait).After(deadline) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (c *Config) shouldRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ambiguousRetriesDisabled(ctx) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return err == nil && resp.StatusCode == http.StatusTooManyRequests, nil
+	}
+
+	if err != nil || len(c.RetryStatusCodes) == 0 {
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	// Do not retry on context.Canceled or context.DeadlineExceeded
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || slices.Contains(c.RetryStatusCodes, resp.StatusCode) {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (c *Config) backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := c.rateLimitWait(resp); ok {
+			return wait
+		}
+	}
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, resp)
+}
+
+// rateLimitWait returns how long to wait before retrying a request that was
+// rate limited, up to MaxRateLimitWait.
+func (c *Config) rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	rateLimitErr, ok := sentry.CheckResponse(resp).(*sentry.RateLimitError)
+	if !ok {
+		return 0, false
+	}
+
+	maxWait := c.MaxRateLimitWait
+	if maxWait <= 0 {
+		maxWait = DefaultMaxRateLimitWait
+	}
+
+	wait := time.Until(rateLimitErr.Rate.Reset)
+	if wait > maxWait {
+		wait = maxWait
+	}
+	return wait, true
 }
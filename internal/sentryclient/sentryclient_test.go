@@ -0,0 +1,257 @@
+package sentryclient
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func TestConfigCheckRetry(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		retryStatusCodes []int
+		statusCode       int
+		want             bool
+	}{
+		"default 500": {
+			statusCode: http.StatusInternalServerError,
+			want:       true,
+		},
+		"default 501": {
+			statusCode: http.StatusNotImplemented,
+			want:       false,
+		},
+		"default 404": {
+			statusCode: http.StatusNotFound,
+			want:       false,
+		},
+		"custom match": {
+			retryStatusCodes: []int{http.StatusConflict},
+			statusCode:       http.StatusConflict,
+			want:             true,
+		},
+		"custom no match": {
+			retryStatusCodes: []int{http.StatusConflict},
+			statusCode:       http.StatusInternalServerError,
+			want:             false,
+		},
+		"custom always retries rate limits": {
+			retryStatusCodes: []int{http.StatusConflict},
+			statusCode:       http.StatusTooManyRequests,
+			want:             true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &Config{RetryStatusCodes: testCase.retryStatusCodes}
+			got, err := c.checkRetry(context.Background(), &http.Response{StatusCode: testCase.statusCode}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != testCase.want {
+				t.Errorf("got %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestConfigBackoffCapsRateLimitWait(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"X-Sentry-Rate-Limit-Remaining": []string{"0"},
+			"X-Sentry-Rate-Limit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+		},
+		Body: http.NoBody,
+	}
+
+	c := &Config{MaxRateLimitWait: 5 * time.Second}
+	if got := c.backoff(time.Second, 30*time.Second, 1, resp); got != 5*time.Second {
+		t.Errorf("got %s, want %s", got, 5*time.Second)
+	}
+}
+
+func TestConfigCheckRetryRespectsDeadline(t *testing.T) {
+	t.Parallel()
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"X-Sentry-Rate-Limit-Remaining": []string{"0"},
+			"X-Sentry-Rate-Limit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)},
+		},
+		Body: http.NoBody,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	c := &Config{}
+	got, err := c.checkRetry(ctx, resp, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got {
+		t.Error("got retry, want no retry past the deadline")
+	}
+
+	if got, _ := c.checkRetry(context.Background(), resp, nil); !got {
+		t.Error("got no retry, want retry without a deadline")
+	}
+}
+
+func TestConfigClientRetries(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug": "my-org"}`))
+	}))
+	defer srv.Close()
+
+	c := &Config{
+		BaseURL:    srv.URL + "/api/",
+		MaxRetries: sentry.Int(2),
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+	}
+	client, err := c.Client(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	org, _, err := client.Organizations.Get(context.Background(), "my-org")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := sentry.StringValue(org.Slug); got != "my-org" {
+		t.Errorf("got slug %q, want %q", got, "my-org")
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+}
+
+func TestConfigClientWrapTransport(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug": "my-org"}`))
+	}))
+	defer srv.Close()
+
+	var attempts *countingRoundTripper
+	c := &Config{
+		BaseURL:    srv.URL + "/api/",
+		MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond,
+		WrapTransport: func(next http.RoundTripper) http.RoundTripper {
+			attempts = &countingRoundTripper{Delegate: next}
+			return attempts
+		},
+	}
+	client, err := c.Client(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, _, err := client.Organizations.Get(context.Background(), "my-org"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := attempts.count.Load(); got != 2 {
+		t.Errorf("got %d attempts, want 2", got)
+	}
+}
+
+type countingRoundTripper struct {
+	Delegate http.RoundTripper
+
+	count atomic.Int32
+}
+
+func (r *countingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.count.Add(1)
+	return r.Delegate.RoundTrip(req)
+}
+
+func TestConfigClientCACert(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"slug": "my-org"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	caCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	testCases := map[string]struct {
+		config  Config
+		wantErr bool
+	}{
+		"untrusted": {
+			config:  Config{MaxRetries: sentry.Int(0)},
+			wantErr: true,
+		},
+		"ca cert": {
+			config: Config{MaxRetries: sentry.Int(0), CACert: caCert},
+		},
+		"insecure skip verify": {
+			config: Config{MaxRetries: sentry.Int(0), InsecureSkipVerify: true},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			testCase.config.BaseURL = srv.URL + "/api/"
+			client, err := testCase.config.Client(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			_, _, err = client.Organizations.Get(context.Background(), "my-org")
+			if gotErr := err != nil; gotErr != testCase.wantErr {
+				t.Errorf("got error %v, want error %t", err, testCase.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigClientCertRequiresKey(t *testing.T) {
+	t.Parallel()
+
+	c := &Config{ClientCert: "-----BEGIN CERTIFICATE-----"}
+	if _, err := c.Client(context.Background()); err == nil {
+		t.Error("expected an error")
+	}
+}
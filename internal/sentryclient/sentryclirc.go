@@ -0,0 +1,148 @@
+package sentryclient
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// CliConfig holds the settings read from the `.sentryclirc` files used by
+// sentry-cli.
+type CliConfig struct {
+	// Token is `auth.token`.
+	Token string
+	// URL is `defaults.url`, the Sentry server URL without the `/api/` suffix.
+	URL string
+	// Organization is `defaults.org`.
+	Organization string
+}
+
+// BaseURL returns the API base URL derived from URL, or an empty string if
+// URL is not set.
+func (c *CliConfig) BaseURL() string {
+	if c.URL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(c.URL, "/") + "/api/"
+}
+
+// LoadCliConfig loads the `.sentryclirc` file from the home directory, then
+// the closest one found by walking up from the working directory, the latter
+// taking precedence like sentry-cli does. Missing files are ignored.
+func LoadCliConfig() (*CliConfig, error) {
+	var paths []string
+
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".sentryclirc"))
+	}
+
+	if dir, err := os.Getwd(); err == nil {
+		for {
+			path := filepath.Join(dir, ".sentryclirc")
+			if _, err := os.Stat(path); err == nil {
+				if len(paths) == 0 || paths[0] != path {
+					paths = append(paths, path)
+				}
+				break
+			}
+
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				break
+			}
+			dir = parent
+		}
+	}
+
+	config := &CliConfig{}
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		err = config.parse(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// ApplyCliConfig falls back to the `.sentryclirc` files when no token is
+// configured, that is when token is empty and tokenSource is nil. The files
+// are not read otherwise, so that a configured token is never sent to the
+// server of the files. Their server and organization are only used together
+// with their own token, and only fill the base URL and organization that are
+// not configured.
+func ApplyCliConfig(token *string, tokenSource oauth2.TokenSource, baseURL *string, organization *string) error {
+	if *token != "" || tokenSource != nil {
+		return nil
+	}
+
+	config, err := LoadCliConfig()
+	if err != nil {
+		return err
+	}
+	config.apply(token, baseURL, organization)
+	return nil
+}
+
+func (c *CliConfig) apply(token *string, baseURL *string, organization *string) {
+	if c.Token == "" {
+		return
+	}
+
+	*token = c.Token
+	if *baseURL == "" {
+		*baseURL = c.BaseURL()
+	}
+	if *organization == "" {
+		*organization = c.Organization
+	}
+}
+
+// parse reads an INI formatted `.sentryclirc` file, overriding the settings
+// that are present.
+func (c *CliConfig) parse(r io.Reader) error {
+	var section string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch section + "." + key {
+		case "auth.token":
+			c.Token = value
+		case "defaults.url":
+			c.URL = value
+		case "defaults.org":
+			c.Organization = value
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,161 @@
+package sentryclient
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+)
+
+func TestCliConfigParse(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		in   string
+		want CliConfig
+	}{
+		"empty": {
+			in:   "",
+			want: CliConfig{},
+		},
+		"all": {
+			in: `
+; sentry-cli configuration
+[auth]
+token = my-token
+
+[defaults]
+url = https://sentry.example.com/
+org=my-org
+project = my-project
+`,
+			want: CliConfig{
+				Token:        "my-token",
+				URL:          "https://sentry.example.com/",
+				Organization: "my-org",
+			},
+		},
+		"ignores other sections": {
+			in: `
+[http]
+token = not-a-token
+[Auth]
+Token = my-token
+`,
+			want: CliConfig{
+				Token: "my-token",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got CliConfig
+			if err := got.parse(strings.NewReader(testCase.in)); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(testCase.want, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCliConfigBaseURL(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		url  string
+		want string
+	}{
+		"empty":          {url: "", want: ""},
+		"trailing slash": {url: "https://sentry.example.com/", want: "https://sentry.example.com/api/"},
+		"no slash":       {url: "https://sentry.example.com", want: "https://sentry.example.com/api/"},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &CliConfig{URL: testCase.url}
+			if got := c.BaseURL(); got != testCase.want {
+				t.Errorf("got %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestApplyCliConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	err := os.WriteFile(filepath.Join(home, ".sentryclirc"), []byte(`
+[auth]
+token = cli-token
+
+[defaults]
+url = https://sentry.example.com/
+org = cli-org
+`), 0o600)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		token, baseURL, organization             string
+		tokenSource                              oauth2.TokenSource
+		wantToken, wantBaseURL, wantOrganization string
+	}{
+		"no token": {
+			wantToken:        "cli-token",
+			wantBaseURL:      "https://sentry.example.com/api/",
+			wantOrganization: "cli-org",
+		},
+		"no token with base URL and organization": {
+			baseURL:          "https://sentry.io/api/",
+			organization:     "my-org",
+			wantToken:        "cli-token",
+			wantBaseURL:      "https://sentry.io/api/",
+			wantOrganization: "my-org",
+		},
+		"explicit token": {
+			token:     "my-token",
+			wantToken: "my-token",
+		},
+		"token source": {
+			tokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "my-token"}),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			token, baseURL, organization := testCase.token, testCase.baseURL, testCase.organization
+			if err := ApplyCliConfig(&token, testCase.tokenSource, &baseURL, &organization); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if token != testCase.wantToken || baseURL != testCase.wantBaseURL || organization != testCase.wantOrganization {
+				t.Errorf("got %q, %q, %q, want %q, %q, %q", token, baseURL, organization, testCase.wantToken, testCase.wantBaseURL, testCase.wantOrganization)
+			}
+		})
+	}
+}
+
+func TestCliConfigApplyWithoutToken(t *testing.T) {
+	t.Parallel()
+
+	// The server and organization are not used without the token of the file
+	c := &CliConfig{URL: "https://sentry.example.com/", Organization: "cli-org"}
+	var token, baseURL, organization string
+	c.apply(&token, &baseURL, &organization)
+	if token != "" || baseURL != "" || organization != "" {
+		t.Errorf("got %q, %q, %q", token, baseURL, organization)
+	}
+}
@@ -0,0 +1,352 @@
+// Package sentrygrouping parses the issue grouping rules of Sentry projects:
+// the fingerprinting rules and the stack trace rules, which Sentry calls
+// grouping enhancements.
+//
+// The grammars are adapted from
+// https://github.com/getsentry/sentry/blob/24.11.0/src/sentry/grouping/fingerprinting/__init__.py
+// and
+// https://github.com/getsentry/sentry/blob/24.11.0/src/sentry/grouping/enhancer/parser.py
+package sentrygrouping
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SyntaxError is an error in the rules, on a line numbered from 1.
+type SyntaxError struct {
+	Line    int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+var fingerprintingMatchers = map[string]bool{
+	"error.type":     true,
+	"error.value":    true,
+	"stack.abs_path": true,
+	"stack.module":   true,
+	"stack.function": true,
+	"stack.package":  true,
+	"message":        true,
+	"logger":         true,
+	"level":          true,
+	"family":         true,
+	"app":            true,
+	"sdk":            true,
+	"release":        true,
+	"type":           true,
+	"value":          true,
+	"path":           true,
+	"module":         true,
+	"function":       true,
+	"package":        true,
+}
+
+var enhancementsMatchers = map[string]bool{
+	"stack.module":    true,
+	"stack.abs_path":  true,
+	"stack.package":   true,
+	"stack.function":  true,
+	"error.type":      true,
+	"error.value":     true,
+	"error.mechanism": true,
+	"module":          true,
+	"path":            true,
+	"package":         true,
+	"function":        true,
+	"category":        true,
+	"family":          true,
+	"app":             true,
+	"type":            true,
+	"value":           true,
+	"mechanism":       true,
+}
+
+var (
+	keyRegexp         = regexp.MustCompile(`^[a-zA-Z0-9_.-]+`)
+	quotedKeyRegexp   = regexp.MustCompile(`^"([a-zA-Z0-9_.:-]+)"`)
+	quotedRegexp      = regexp.MustCompile(`^"([^"\\]*(?:\\.[^"\\]*)*)"`)
+	unquotedRegexp    = regexp.MustCompile(`^\S+`)
+	fpAttributeRegexp = regexp.MustCompile(`^([a-zA-Z0-9_.-]+)=`)
+	fpVariableRegexp  = regexp.MustCompile(`^\{\{\s*\S+?\s*\}\}`)
+	fpUnquotedRegexp  = regexp.MustCompile(`^[^\s{,]+`)
+	flagActionRegexp  = regexp.MustCompile(`^[\^v]?[+-](group|app|prefix|sentinel)\b`)
+	varActionRegexp   = regexp.MustCompile(`^(max-frames|min-frames|invert-stacktrace|category)\s*=\s*\S+`)
+)
+
+// scanner scans a line of rules.
+type scanner struct {
+	line string
+	pos  int
+}
+
+func (s *scanner) skipSpace() {
+	for s.pos < len(s.line) && (s.line[s.pos] == ' ' || s.line[s.pos] == '\t') {
+		s.pos++
+	}
+}
+
+func (s *scanner) done() bool {
+	s.skipSpace()
+	return s.pos == len(s.line)
+}
+
+func (s *scanner) rest() string {
+	return s.line[s.pos:]
+}
+
+func (s *scanner) consume(prefix string) bool {
+	if strings.HasPrefix(s.rest(), prefix) {
+		s.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (s *scanner) match(re *regexp.Regexp) (string, bool) {
+	m := re.FindString(s.rest())
+	if m == "" {
+		return "", false
+	}
+	s.pos += len(m)
+	return m, true
+}
+
+// matcher scans a matcher such as `!stack.module:foo`. It returns false
+// without consuming anything if there is no matcher at the position. Matchers
+// of tags, such as `tags.server_name:foo`, are only accepted if allowTags is
+// true, as the grouping enhancements have none.
+func (s *scanner) matcher(matchers map[string]bool, allowTags bool) (bool, error) {
+	s.skipSpace()
+	start := s.pos
+
+	s.consume("!")
+	var key string
+	if m := quotedKeyRegexp.FindStringSubmatch(s.rest()); m != nil {
+		key = m[1]
+		s.pos += len(m[0])
+	} else if m, ok := s.match(keyRegexp); ok {
+		key = m
+	}
+	if key == "" || !s.consume(":") {
+		s.pos = start
+		return false, nil
+	}
+
+	if !matchers[key] && !(allowTags && strings.HasPrefix(key, "tags.")) {
+		return false, fmt.Errorf("unknown matcher %q", key)
+	}
+	if _, ok := s.match(quotedRegexp); ok {
+		return true, nil
+	}
+	if strings.HasPrefix(s.rest(), `"`) {
+		return false, fmt.Errorf("unterminated quoted value of matcher %q", key)
+	}
+	if _, ok := s.match(unquotedRegexp); !ok {
+		return false, fmt.Errorf("missing value of matcher %q", key)
+	}
+	return true, nil
+}
+
+// lines calls fn with the scanner of each line that is not empty or a
+// comment, and returns its error as a SyntaxError.
+func lines(rules string, fn func(s *scanner) error) error {
+	for i, line := range strings.Split(rules, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := fn(&scanner{line: line}); err != nil {
+			return &SyntaxError{Line: i + 1, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// ValidateFingerprintingRules checks the syntax of fingerprinting rules, such
+// as `error.type:DatabaseUnavailable -> system-down`.
+func ValidateFingerprintingRules(rules string) error {
+	return lines(rules, func(s *scanner) error {
+		matchers := 0
+		for {
+			ok, err := s.matcher(fingerprintingMatchers, true)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			matchers++
+		}
+		if matchers == 0 {
+			return fmt.Errorf("expected a matcher such as `error.type:value`, got %q", s.rest())
+		}
+
+		s.skipSpace()
+		if !s.consume("->") {
+			return fmt.Errorf("expected `->` after the matchers, got %q", s.rest())
+		}
+
+		values := 0
+		for !s.done() {
+			switch {
+			case fpAttributeRegexp.MatchString(s.rest()):
+				key := fpAttributeRegexp.FindStringSubmatch(s.rest())[1]
+				s.match(fpAttributeRegexp)
+				if key != "title" {
+					return fmt.Errorf("unknown fingerprint attribute %q", key)
+				}
+				if _, ok := s.match(quotedRegexp); !ok {
+					return fmt.Errorf("the value of the fingerprint attribute %q must be quoted", key)
+				}
+			case strings.HasPrefix(s.rest(), `"`):
+				if _, ok := s.match(quotedRegexp); !ok {
+					return fmt.Errorf("unterminated quoted fingerprint value")
+				}
+				values++
+			case strings.HasPrefix(s.rest(), "{"):
+				if _, ok := s.match(fpVariableRegexp); !ok {
+					return fmt.Errorf("invalid fingerprint variable %q", s.rest())
+				}
+				values++
+			default:
+				if _, ok := s.match(fpUnquotedRegexp); !ok {
+					return fmt.Errorf("invalid fingerprint value %q", s.rest())
+				}
+				values++
+			}
+			s.skipSpace()
+			s.consume(",")
+		}
+		if values == 0 {
+			return fmt.Errorf("expected a fingerprint after `->`")
+		}
+		return nil
+	})
+}
+
+// ValidateEnhancements checks the syntax of stack trace rules, such as
+// `stack.function:panic_handler ^-group`.
+func ValidateEnhancements(rules string) error {
+	return lines(rules, func(s *scanner) error {
+		// An optional caller matcher, such as `[ function:foo ] |`
+		s.skipSpace()
+		if s.consume("[") {
+			if err := bracketedMatcher(s); err != nil {
+				return err
+			}
+			s.skipSpace()
+			if !s.consume("|") {
+				return fmt.Errorf("expected `|` after the caller matcher, got %q", s.rest())
+			}
+		}
+
+		matchers := 0
+		for {
+			ok, err := s.matcher(enhancementsMatchers, false)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				break
+			}
+			matchers++
+		}
+		if matchers == 0 {
+			return fmt.Errorf("expected a matcher such as `stack.function:value`, got %q", s.rest())
+		}
+
+		// An optional callee matcher, such as `| [ function:foo ]`
+		s.skipSpace()
+		if s.consume("|") {
+			s.skipSpace()
+			if !s.consume("[") {
+				return fmt.Errorf("expected `[` after `|`, got %q", s.rest())
+			}
+			if err := bracketedMatcher(s); err != nil {
+				return err
+			}
+		}
+
+		actions := 0
+		for !s.done() {
+			if _, ok := s.match(flagActionRegexp); ok {
+				actions++
+				continue
+			}
+			if _, ok := s.match(varActionRegexp); ok {
+				actions++
+				continue
+			}
+			return fmt.Errorf("expected an action such as `+app`, `-group` or `max-frames=3`, got %q", s.rest())
+		}
+		if actions == 0 {
+			return fmt.Errorf("expected an action after the matchers")
+		}
+		return nil
+	})
+}
+
+// bracketedMatcher scans the rest of a matcher in brackets after `[`.
+func bracketedMatcher(s *scanner) error {
+	ok, err := s.matcher(enhancementsMatchers, false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("expected a matcher after `[`, got %q", s.rest())
+	}
+	s.skipSpace()
+	if !s.consume("]") {
+		return fmt.Errorf("expected `]` after the matcher, got %q", s.rest())
+	}
+	return nil
+}
+
+// Normalize returns rules without comments, empty lines and the whitespace
+// that does not change their meaning, so that rules that only differ in
+// those can be compared.
+func Normalize(rules string) string {
+	var normalized []string
+	for _, line := range strings.Split(rules, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Collapse the whitespace outside of quoted values
+		var b strings.Builder
+		quoted, escaped, space := false, false, false
+		for _, r := range line {
+			switch {
+			case quoted:
+				b.WriteRune(r)
+				if escaped {
+					escaped = false
+				} else if r == '\\' {
+					escaped = true
+				} else if r == '"' {
+					quoted = false
+				}
+				continue
+			case r == ' ' || r == '\t':
+				space = true
+				continue
+			}
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			if r == '"' {
+				quoted = true
+			}
+			b.WriteRune(r)
+		}
+		normalized = append(normalized, b.String())
+	}
+	return strings.Join(normalized, "\n")
+}
@@ -0,0 +1,84 @@
+package sentrygrouping
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateFingerprintingRules(t *testing.T) {
+	testCases := []struct {
+		rules    string
+		wantLine int
+	}{
+		{"error.type:DatabaseUnavailable -> system-down", 0},
+		{"# comment\n\nerror.type:ConnectionError -> {{ default }}, connection-error title=\"Connection error\"", 0},
+		{"stack.function:\"my function\" !tags.server_name:*.local -> {{ function }}", 0},
+		{"logger:my.logger level:error -> \"quoted value\"", 0},
+		{"error.type:A -> a\nerror.type:B b", 2},
+		{"error.type:A ->", 1},
+		{"-> a", 1},
+		{"bogus:A -> a", 1},
+		{"error.type:A -> a colour=\"red\"", 1},
+		{"message:\"unterminated -> a", 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.rules, func(t *testing.T) {
+			assertLine(t, ValidateFingerprintingRules(tc.rules), tc.wantLine)
+		})
+	}
+}
+
+func TestValidateEnhancements(t *testing.T) {
+	testCases := []struct {
+		rules    string
+		wantLine int
+	}{
+		{"stack.function:panic_handler ^-group", 0},
+		{"# comment\nstack.module:myapp.* +app\nfamily:native max-frames=3", 0},
+		{"[ stack.function:foo ] | stack.function:bar -group", 0},
+		{"stack.function:bar | [ stack.function:foo ] +group invert-stacktrace = 1", 0},
+		{"stack.module:foo +app\nstack.module:bar", 2},
+		{"+app", 1},
+		{"bogus:foo +app", 1},
+		{"stack.module:foo +app\ntags.foo:bar +app", 2},
+		{"stack.module:foo +bogus", 1},
+		{"[ stack.function:foo ] stack.function:bar -group", 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.rules, func(t *testing.T) {
+			assertLine(t, ValidateEnhancements(tc.rules), tc.wantLine)
+		})
+	}
+}
+
+func assertLine(t *testing.T, err error, wantLine int) {
+	t.Helper()
+
+	if wantLine == 0 {
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		return
+	}
+
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("got error %v, want a syntax error", err)
+	}
+	if syntaxErr.Line != wantLine {
+		t.Errorf("got line %d, want %d: %s", syntaxErr.Line, wantLine, err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	a := "# grouping\nerror.type:A   ->   a\n\n  stack.function:\"my  function\" -> b  \n"
+	b := "error.type:A -> a\nstack.function:\"my  function\" -> b"
+	if got, want := Normalize(a), Normalize(b); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Whitespace in quoted values is significant
+	if Normalize(`message:"a  b" -> a`) == Normalize(`message:"a b" -> a`) {
+		t.Error("quoted values must not be normalized")
+	}
+}
@@ -0,0 +1,91 @@
+// Package sentryownership parses the ownership rules of Sentry projects.
+//
+// The grammar is adapted from
+// https://github.com/getsentry/sentry/blob/24.11.0/src/sentry/ownership/grammar.py
+package sentryownership
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SyntaxError is an error in the rules, on a line numbered from 1.
+type SyntaxError struct {
+	Line    int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Rule is a rule such as `path:src/billing/* #billing jane@example.com`.
+type Rule struct {
+	Line        int
+	MatcherType string
+	Pattern     string
+	Owners      []string
+}
+
+var (
+	matcherRegexp = regexp.MustCompile(`^(url|path|module|codeowners|tags\.[^:\s]+):("(?:[^"\\]*(?:\\.[^"\\]*)*)"|\S+)$`)
+	quotedPrefix  = regexp.MustCompile(`^(url|path|module|codeowners|tags\.[^:\s]+):"(?:[^"\\]*(?:\\.[^"\\]*)*)"`)
+)
+
+// IsTeam reports whether owner is a team, such as `#billing`, rather than the
+// email of a member.
+func IsTeam(owner string) bool {
+	return strings.HasPrefix(owner, "#")
+}
+
+// Parse parses ownership rules. Each line that is not empty or a comment is a
+// matcher followed by one or more owners, which are teams or emails.
+func Parse(raw string) ([]Rule, error) {
+	var rules []Rule
+	for i, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseRule(line)
+		if err != nil {
+			return nil, &SyntaxError{Line: i + 1, Message: err.Error()}
+		}
+		rule.Line = i + 1
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseRule(line string) (Rule, error) {
+	// A quoted pattern may contain whitespace
+	matcher := quotedPrefix.FindString(line)
+	if matcher == "" {
+		matcher, _, _ = strings.Cut(strings.ReplaceAll(line, "\t", " "), " ")
+	}
+	m := matcherRegexp.FindStringSubmatch(matcher)
+	if m == nil {
+		return Rule{}, fmt.Errorf("expected a matcher such as `path:src/*`, `url:*/checkout/*`, `module:billing.*` or `tags.sku_class:enterprise`, got %q", matcher)
+	}
+
+	rule := Rule{
+		MatcherType: m[1],
+		Pattern:     m[2],
+		Owners:      strings.Fields(line[len(matcher):]),
+	}
+	if len(rule.Owners) == 0 {
+		return Rule{}, fmt.Errorf("expected owners after %q", matcher)
+	}
+	for _, owner := range rule.Owners {
+		if IsTeam(owner) {
+			if len(owner) == 1 {
+				return Rule{}, fmt.Errorf("missing the slug of team %q", owner)
+			}
+		} else if !strings.Contains(owner, "@") {
+			return Rule{}, fmt.Errorf("owner %q must be a team such as `#billing` or the email of a member", owner)
+		}
+	}
+	return rule, nil
+}
@@ -0,0 +1,57 @@
+package sentryownership
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	rules, err := Parse(`
+# Billing
+path:src/billing/* #billing jane@example.com
+url:"*/checkout page/*"	#checkout
+tags.sku_class:enterprise #enterprise
+module:com.example.billing.*   #billing
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []Rule{
+		{Line: 3, MatcherType: "path", Pattern: "src/billing/*", Owners: []string{"#billing", "jane@example.com"}},
+		{Line: 4, MatcherType: "url", Pattern: `"*/checkout page/*"`, Owners: []string{"#checkout"}},
+		{Line: 5, MatcherType: "tags.sku_class", Pattern: "enterprise", Owners: []string{"#enterprise"}},
+		{Line: 6, MatcherType: "module", Pattern: "com.example.billing.*", Owners: []string{"#billing"}},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("got %+v, want %+v", rules, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		raw      string
+		wantLine int
+	}{
+		{"path:src/* #team\nsrc/* #team", 2},
+		{"file:src/* #team", 1},
+		{"path:src/*", 1},
+		{"path:src/* jane", 1},
+		{"path:src/* #", 1},
+		{"path: #team", 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.raw, func(t *testing.T) {
+			_, err := Parse(tc.raw)
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("got error %v, want a syntax error", err)
+			}
+			if syntaxErr.Line != tc.wantLine {
+				t.Errorf("got line %d, want %d: %s", syntaxErr.Line, tc.wantLine, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,884 @@
+package sentrytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/pkg/must"
+)
+
+// orgRoles are the organization roles of every organization.
+var orgRoles = []sentry.OrganizationRoleListItem{
+	{ID: "member", Name: "Member", IsAllowed: true, MinimumTeamRole: "contributor", Scopes: []string{"event:read", "event:write", "member:read", "org:read", "project:read", "project:releases", "team:read", "alerts:read", "alerts:write"}},
+	{ID: "admin", Name: "Admin", IsAllowed: true, MinimumTeamRole: "admin", Scopes: []string{"event:admin", "member:read", "org:read", "project:admin", "project:read", "project:write", "team:admin", "team:read", "team:write", "alerts:read", "alerts:write"}},
+	{ID: "manager", Name: "Manager", IsAllowed: true, MinimumTeamRole: "admin", Scopes: []string{"event:admin", "member:admin", "org:read", "org:integrations", "project:admin", "team:admin", "alerts:read", "alerts:write"}},
+	{ID: "owner", Name: "Owner", IsAllowed: true, IsGlobal: true, MinimumTeamRole: "admin", Scopes: AllScopes},
+	{ID: "billing", Name: "Billing", IsAllowed: true, MinimumTeamRole: "contributor", Scopes: []string{"org:billing"}},
+}
+
+// teamRoles are the team roles of every organization.
+var teamRoles = []sentry.TeamRoleListItem{
+	{ID: "contributor", Name: "Contributor", IsAllowed: true, Scopes: []string{"event:read", "event:write", "project:read", "project:releases", "team:read"}},
+	{ID: "admin", Name: "Team Admin", IsAllowed: true, Scopes: []string{"event:admin", "project:admin", "project:read", "project:write", "team:admin", "team:read", "team:write"}},
+}
+
+type organization struct {
+	ID          string
+	Slug        string
+	Name        string
+	DateCreated time.Time
+
+	relayPiiConfig *string
+
+	teams               []*sentry.Team
+	projects            []*project
+	members             []*sentry.OrganizationMember
+	integrations        []*sentry.OrganizationIntegration
+	notificationActions []*sentry.NotificationAction
+	dashboards          []*sentry.Dashboard
+}
+
+type organizationResponse struct {
+	sentry.Organization
+	Links map[string]string `json:"links"`
+}
+
+func (s *Server) addOrganization(slug string, name string) *organization {
+	org := &organization{
+		ID:          s.newID(),
+		Slug:        slug,
+		Name:        name,
+		DateCreated: time.Now().UTC(),
+	}
+	org.members = append(org.members, &sentry.OrganizationMember{
+		ID:           s.newID(),
+		Email:        "owner@example.com",
+		Name:         "Owner",
+		User:         sentry.User{ID: "1", Email: "owner@example.com", Name: "Owner"},
+		OrgRole:      "owner",
+		DateCreated:  org.DateCreated,
+		InviteStatus: "approved",
+		TeamRoles:    []sentry.TeamRole{},
+		Teams:        []string{},
+	})
+	s.organizations = append(s.organizations, org)
+	return org
+}
+
+func (org *organization) response(r *http.Request) organizationResponse {
+	url := "http://" + r.Host
+	return organizationResponse{
+		Organization: sentry.Organization{
+			ID:           sentry.String(org.ID),
+			Slug:         sentry.String(org.Slug),
+			Name:         sentry.String(org.Name),
+			DateCreated:  sentry.Time(org.DateCreated),
+			Status:       &sentry.OrganizationStatus{ID: sentry.String("active"), Name: sentry.String("active")},
+			Features:     []string{"team-roles"},
+			DefaultRole:  sentry.String("member"),
+			OrgRoleList:  orgRoles,
+			TeamRoleList: teamRoles,
+			Role:         sentry.String("owner"),
+			Access:       AllScopes,
+
+			RelayPiiConfig: org.relayPiiConfig,
+		},
+		Links: map[string]string{
+			"organizationUrl": url,
+			"regionUrl":       url,
+		},
+	}
+}
+
+// organization returns the organization of a request, writing a 404 response
+// if it does not exist.
+func (s *Server) organization(w http.ResponseWriter, v vars) (*organization, bool) {
+	org := s.findOrganization(v["org"])
+	if org == nil {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return nil, false
+	}
+	return org, true
+}
+
+func (s *Server) listOrganizations(w http.ResponseWriter, r *http.Request, _ vars) {
+	items := make([]organizationResponse, 0, len(s.organizations))
+	for _, org := range s.organizations {
+		items = append(items, org.response(r))
+	}
+	writeList(s, w, r, items)
+}
+
+func (s *Server) createOrganization(w http.ResponseWriter, r *http.Request, _ vars) {
+	var params sentry.CreateOrganizationParams
+	if !decode(w, r, &params) {
+		return
+	}
+	if params.Name == nil || *params.Name == "" {
+		writeError(w, http.StatusBadRequest, "name: This field is required.")
+		return
+	}
+
+	slug := slugify(*params.Name)
+	if params.Slug != nil && *params.Slug != "" {
+		slug = *params.Slug
+	}
+	if s.findOrganization(slug) != nil {
+		writeError(w, http.StatusConflict, "An organization with this slug already exists.")
+		return
+	}
+
+	org := s.addOrganization(slug, *params.Name)
+	writeJSON(w, http.StatusCreated, org.response(r))
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, org.response(r))
+}
+
+func (s *Server) updateOrganization(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	var params struct {
+		sentry.UpdateOrganizationParams
+		RelayPiiConfig *string `json:"relayPiiConfig"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+	if !validRelayPiiConfig(params.RelayPiiConfig) {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"relayPiiConfig": {"Invalid JSON value."}})
+		return
+	}
+	if params.Slug != nil && *params.Slug != org.Slug {
+		if s.findOrganization(*params.Slug) != nil {
+			writeError(w, http.StatusConflict, "An organization with this slug already exists.")
+			return
+		}
+		org.Slug = *params.Slug
+	}
+	if params.Name != nil {
+		org.Name = *params.Name
+	}
+	if params.RelayPiiConfig != nil {
+		org.relayPiiConfig = params.RelayPiiConfig
+	}
+	writeJSON(w, http.StatusOK, org.response(r))
+}
+
+func (s *Server) deleteOrganization(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+	remove(&s.organizations, func(o *organization) bool { return o == org })
+	writeJSON(w, http.StatusAccepted, org.response(r))
+}
+
+// Teams
+
+func (org *organization) team(slug string) *sentry.Team {
+	for _, team := range org.teams {
+		if *team.Slug == slug || *team.ID == slug {
+			return team
+		}
+	}
+	return nil
+}
+
+// team returns the team of a request, writing a 404 response if it does not
+// exist.
+func (s *Server) team(w http.ResponseWriter, v vars) (*organization, *sentry.Team, bool) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+	team := org.team(v["team"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return nil, nil, false
+	}
+	return org, team, true
+}
+
+func (org *organization) teamResponse(team *sentry.Team) sentry.Team {
+	memberCount := 0
+	for _, member := range org.members {
+		if slices.Contains(member.Teams, *team.Slug) {
+			memberCount++
+		}
+	}
+
+	out := *team
+	out.MemberCount = sentry.Int(memberCount)
+	return out
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	items := make([]sentry.Team, 0, len(org.teams))
+	for _, team := range org.teams {
+		items = append(items, org.teamResponse(team))
+	}
+	writeList(s, w, r, items)
+}
+
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.CreateTeamParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	var name, slug string
+	if params.Name != nil {
+		name = *params.Name
+	}
+	if params.Slug != nil {
+		slug = *params.Slug
+	}
+	if slug == "" {
+		slug = slugify(name)
+	}
+	if name == "" {
+		name = slug
+	}
+	if slug == "" {
+		writeError(w, http.StatusBadRequest, "slug: This field is required.")
+		return
+	}
+	if org.team(slug) != nil {
+		writeError(w, http.StatusConflict, "A team with this slug already exists.")
+		return
+	}
+
+	team := &sentry.Team{
+		ID:          sentry.String(s.newID()),
+		Slug:        sentry.String(slug),
+		Name:        sentry.String(name),
+		DateCreated: sentry.Time(time.Now().UTC()),
+		IsMember:    sentry.Bool(false),
+		HasAccess:   sentry.Bool(true),
+		IsPending:   sentry.Bool(false),
+		Avatar:      &sentry.Avatar{Type: "letter_avatar"},
+	}
+	org.teams = append(org.teams, team)
+	writeJSON(w, http.StatusCreated, org.teamResponse(team))
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request, v vars) {
+	org, team, ok := s.team(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, org.teamResponse(team))
+}
+
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request, v vars) {
+	org, team, ok := s.team(w, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.UpdateTeamParams
+	if !decode(w, r, &params) {
+		return
+	}
+	if params.Slug != nil && *params.Slug != *team.Slug {
+		if org.team(*params.Slug) != nil {
+			writeError(w, http.StatusConflict, "A team with this slug already exists.")
+			return
+		}
+		for _, member := range org.members {
+			for i := range member.Teams {
+				if member.Teams[i] == *team.Slug {
+					member.Teams[i] = *params.Slug
+				}
+			}
+			for i := range member.TeamRoles {
+				if member.TeamRoles[i].TeamSlug == *team.Slug {
+					member.TeamRoles[i].TeamSlug = *params.Slug
+				}
+			}
+		}
+		team.Slug = sentry.String(*params.Slug)
+	}
+	if params.Name != nil {
+		team.Name = sentry.String(*params.Name)
+	}
+	writeJSON(w, http.StatusOK, org.teamResponse(team))
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request, v vars) {
+	org, team, ok := s.team(w, v)
+	if !ok {
+		return
+	}
+
+	remove(&org.teams, func(t *sentry.Team) bool { return t == team })
+	for _, p := range org.projects {
+		remove(&p.teams, func(t *sentry.Team) bool { return t == team })
+	}
+	for _, member := range org.members {
+		remove(&member.Teams, func(slug string) bool { return slug == *team.Slug })
+		remove(&member.TeamRoles, func(role sentry.TeamRole) bool { return role.TeamSlug == *team.Slug })
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Members
+
+func (org *organization) member(id string) *sentry.OrganizationMember {
+	for _, member := range org.members {
+		if member.ID == id {
+			return member
+		}
+	}
+	return nil
+}
+
+// member returns the member of a request, writing a 404 response if it does
+// not exist.
+func (s *Server) member(w http.ResponseWriter, v vars) (*organization, *sentry.OrganizationMember, bool) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+	member := org.member(v["member"])
+	if member == nil {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return nil, nil, false
+	}
+	return org, member, true
+}
+
+func memberResponse(member *sentry.OrganizationMember) sentry.OrganizationMember {
+	out := *member
+	out.OrgRoleList = orgRoles
+	out.TeamRoleList = teamRoles
+	return out
+}
+
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	items := make([]sentry.OrganizationMember, 0, len(org.members))
+	for _, member := range org.members {
+		items = append(items, memberResponse(member))
+	}
+	writeList(s, w, r, items)
+}
+
+func (s *Server) createMember(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.CreateOrganizationMemberParams
+	if !decode(w, r, &params) {
+		return
+	}
+	if params.Email == "" {
+		writeError(w, http.StatusBadRequest, "email: This field is required.")
+		return
+	}
+	if !slices.ContainsFunc(orgRoles, func(role sentry.OrganizationRoleListItem) bool { return role.ID == params.Role }) {
+		writeError(w, http.StatusBadRequest, "role: Invalid role.")
+		return
+	}
+	for _, member := range org.members {
+		if member.Email == params.Email {
+			writeError(w, http.StatusConflict, "The user "+params.Email+" has already been invited")
+			return
+		}
+	}
+
+	member := &sentry.OrganizationMember{
+		ID:           s.newID(),
+		Email:        params.Email,
+		Name:         params.Email,
+		OrgRole:      params.Role,
+		Pending:      true,
+		DateCreated:  time.Now().UTC(),
+		InviteStatus: "approved",
+		TeamRoles:    []sentry.TeamRole{},
+		Teams:        []string{},
+	}
+	for _, slug := range params.Teams {
+		if org.team(slug) == nil {
+			writeError(w, http.StatusBadRequest, "teams: Invalid team: "+slug)
+			return
+		}
+		member.Teams = append(member.Teams, slug)
+		member.TeamRoles = append(member.TeamRoles, sentry.TeamRole{TeamSlug: slug})
+	}
+	org.members = append(org.members, member)
+	writeJSON(w, http.StatusCreated, memberResponse(member))
+}
+
+func (s *Server) getMember(w http.ResponseWriter, r *http.Request, v vars) {
+	_, member, ok := s.member(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, memberResponse(member))
+}
+
+func (s *Server) updateMember(w http.ResponseWriter, r *http.Request, v vars) {
+	org, member, ok := s.member(w, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.UpdateOrganizationMemberParams
+	if !decode(w, r, &params) {
+		return
+	}
+	if params.OrganizationRole != "" {
+		if !slices.ContainsFunc(orgRoles, func(role sentry.OrganizationRoleListItem) bool { return role.ID == params.OrganizationRole }) {
+			writeError(w, http.StatusBadRequest, "role: Invalid role.")
+			return
+		}
+		member.OrgRole = params.OrganizationRole
+	}
+	if params.TeamRoles != nil {
+		teams := []string{}
+		for _, role := range params.TeamRoles {
+			if org.team(role.TeamSlug) == nil {
+				writeError(w, http.StatusBadRequest, "teamRoles: Invalid team: "+role.TeamSlug)
+				return
+			}
+			teams = append(teams, role.TeamSlug)
+		}
+		member.Teams = teams
+		member.TeamRoles = params.TeamRoles
+	}
+	writeJSON(w, http.StatusOK, memberResponse(member))
+}
+
+func (s *Server) deleteMember(w http.ResponseWriter, r *http.Request, v vars) {
+	org, member, ok := s.member(w, v)
+	if !ok {
+		return
+	}
+	remove(&org.members, func(m *sentry.OrganizationMember) bool { return m == member })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) teamMember(w http.ResponseWriter, v vars) (*organization, *sentry.OrganizationMember, *sentry.Team, bool) {
+	org, member, ok := s.member(w, v)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	team := org.team(v["team"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return nil, nil, nil, false
+	}
+	return org, member, team, true
+}
+
+func (s *Server) addTeamMember(w http.ResponseWriter, r *http.Request, v vars) {
+	org, member, team, ok := s.teamMember(w, v)
+	if !ok {
+		return
+	}
+
+	status := http.StatusNoContent
+	if !slices.Contains(member.Teams, *team.Slug) {
+		member.Teams = append(member.Teams, *team.Slug)
+		member.TeamRoles = append(member.TeamRoles, sentry.TeamRole{TeamSlug: *team.Slug})
+		status = http.StatusCreated
+	}
+	writeJSON(w, status, org.teamResponse(team))
+}
+
+func (s *Server) updateTeamMember(w http.ResponseWriter, r *http.Request, v vars) {
+	_, member, team, ok := s.teamMember(w, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.UpdateTeamMemberParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	i := slices.IndexFunc(member.TeamRoles, func(role sentry.TeamRole) bool { return role.TeamSlug == *team.Slug })
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return
+	}
+	if params.TeamRole != nil {
+		if !slices.ContainsFunc(teamRoles, func(role sentry.TeamRoleListItem) bool { return role.ID == *params.TeamRole }) {
+			writeError(w, http.StatusBadRequest, "teamRole: Invalid team role.")
+			return
+		}
+		member.TeamRoles[i].Role = sentry.String(*params.TeamRole)
+	}
+
+	teamRole := "contributor"
+	if member.TeamRoles[i].Role != nil {
+		teamRole = *member.TeamRoles[i].Role
+	}
+	writeJSON(w, http.StatusOK, sentry.UpdateTeamMemberResponse{
+		IsActive: sentry.Bool(true),
+		TeamRole: sentry.String(teamRole),
+	})
+}
+
+func (s *Server) deleteTeamMember(w http.ResponseWriter, r *http.Request, v vars) {
+	org, member, team, ok := s.teamMember(w, v)
+	if !ok {
+		return
+	}
+	remove(&member.Teams, func(slug string) bool { return slug == *team.Slug })
+	remove(&member.TeamRoles, func(role sentry.TeamRole) bool { return role.TeamSlug == *team.Slug })
+	writeJSON(w, http.StatusOK, org.teamResponse(team))
+}
+
+// Integrations
+
+func (s *Server) integration(w http.ResponseWriter, v vars) (*sentry.OrganizationIntegration, bool) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return nil, false
+	}
+	for _, integration := range org.integrations {
+		if integration.ID == v["integration"] {
+			return integration, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested resource does not exist")
+	return nil, false
+}
+
+func (s *Server) listIntegrations(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	providerKey := r.URL.Query().Get("provider_key")
+	items := []*sentry.OrganizationIntegration{}
+	for _, integration := range org.integrations {
+		if providerKey == "" || integration.Provider.Key == providerKey {
+			items = append(items, integration)
+		}
+	}
+	writeList(s, w, r, items)
+}
+
+func (s *Server) getIntegration(w http.ResponseWriter, r *http.Request, v vars) {
+	integration, ok := s.integration(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, integration)
+}
+
+func (s *Server) updateIntegrationConfig(w http.ResponseWriter, r *http.Request, v vars) {
+	integration, ok := s.integration(w, v)
+	if !ok {
+		return
+	}
+
+	var config map[string]interface{}
+	if !decode(w, r, &config) {
+		return
+	}
+
+	// Like the PagerDuty and Opsgenie integrations, assign IDs to the new rows
+	// of the tables in the configuration
+	for _, value := range config {
+		rows, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, row := range rows {
+			if row, ok := row.(map[string]interface{}); ok {
+				if id, ok := row["id"]; ok && (id == nil || id == "" || id == "0" || id == float64(0)) {
+					row["id"] = json.Number(s.newID())
+				}
+			}
+		}
+	}
+
+	integration.ConfigData = must.Get(json.Marshal(config))
+	w.WriteHeader(http.StatusOK)
+}
+
+// Notification actions
+
+func (s *Server) notificationAction(w http.ResponseWriter, v vars) (*organization, *sentry.NotificationAction, bool) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+	for _, action := range org.notificationActions {
+		if action.ID.String() == v["action"] {
+			return org, action, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested resource does not exist")
+	return nil, nil, false
+}
+
+// projectIDs returns the IDs of the projects with the given slugs, writing a
+// 400 response if one does not exist.
+func (org *organization) projectIDs(w http.ResponseWriter, slugs []string) ([]json.Number, bool) {
+	ids := []json.Number{}
+	for _, slug := range slugs {
+		p := org.project(slug)
+		if p == nil {
+			writeError(w, http.StatusBadRequest, "projects: Invalid project slug: "+slug)
+			return nil, false
+		}
+		ids = append(ids, json.Number(p.ID))
+	}
+	return ids, true
+}
+
+func (s *Server) saveNotificationAction(w http.ResponseWriter, r *http.Request, org *organization, action *sentry.NotificationAction) bool {
+	var params sentry.CreateNotificationActionParams
+	if !decode(w, r, &params) {
+		return false
+	}
+	if params.TriggerType == nil || params.ServiceType == nil {
+		writeError(w, http.StatusBadRequest, "triggerType and serviceType are required.")
+		return false
+	}
+
+	projects, ok := org.projectIDs(w, params.Projects)
+	if !ok {
+		return false
+	}
+
+	action.TriggerType = params.TriggerType
+	action.ServiceType = params.ServiceType
+	action.IntegrationId = params.IntegrationId
+	action.TargetIdentifier = params.TargetIdentifier
+	action.TargetDisplay = params.TargetDisplay
+	action.TargetType = params.TargetType
+	action.Projects = projects
+	return true
+}
+
+func (s *Server) createNotificationAction(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	action := &sentry.NotificationAction{}
+	if !s.saveNotificationAction(w, r, org, action) {
+		return
+	}
+	id := json.Number(s.newID())
+	action.ID = &id
+	org.notificationActions = append(org.notificationActions, action)
+	writeJSON(w, http.StatusCreated, action)
+}
+
+func (s *Server) getNotificationAction(w http.ResponseWriter, r *http.Request, v vars) {
+	_, action, ok := s.notificationAction(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, action)
+}
+
+func (s *Server) updateNotificationAction(w http.ResponseWriter, r *http.Request, v vars) {
+	org, action, ok := s.notificationAction(w, v)
+	if !ok {
+		return
+	}
+	if !s.saveNotificationAction(w, r, org, action) {
+		return
+	}
+	writeJSON(w, http.StatusAccepted, action)
+}
+
+func (s *Server) deleteNotificationAction(w http.ResponseWriter, r *http.Request, v vars) {
+	org, action, ok := s.notificationAction(w, v)
+	if !ok {
+		return
+	}
+	remove(&org.notificationActions, func(a *sentry.NotificationAction) bool { return a == action })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Dashboards
+
+func (s *Server) dashboard(w http.ResponseWriter, v vars) (*organization, *sentry.Dashboard, bool) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+	for _, dashboard := range org.dashboards {
+		if *dashboard.ID == v["dashboard"] {
+			return org, dashboard, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested resource does not exist")
+	return nil, nil, false
+}
+
+// saveDashboard decodes the request into dashboard, assigning IDs to the new
+// widgets and queries.
+func (s *Server) saveDashboard(w http.ResponseWriter, r *http.Request, dashboard *sentry.Dashboard) bool {
+	var params sentry.Dashboard
+	if !decode(w, r, &params) {
+		return false
+	}
+	if params.Title == nil || *params.Title == "" {
+		writeError(w, http.StatusBadRequest, "title: This field is required.")
+		return false
+	}
+
+	for _, widget := range params.Widgets {
+		if widget.ID == nil {
+			widget.ID = sentry.String(s.newID())
+		}
+		for _, query := range widget.Queries {
+			if query.ID == nil {
+				query.ID = sentry.String(s.newID())
+			}
+		}
+	}
+
+	dashboard.Title = params.Title
+	dashboard.Widgets = params.Widgets
+	return true
+}
+
+func (s *Server) listDashboards(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	// Like Sentry, list the dashboards without their widgets
+	items := make([]sentry.Dashboard, 0, len(org.dashboards))
+	for _, dashboard := range org.dashboards {
+		items = append(items, sentry.Dashboard{
+			ID:          dashboard.ID,
+			Title:       dashboard.Title,
+			DateCreated: dashboard.DateCreated,
+		})
+	}
+	writeList(s, w, r, items)
+}
+
+func (s *Server) createDashboard(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	dashboard := &sentry.Dashboard{}
+	if !s.saveDashboard(w, r, dashboard) {
+		return
+	}
+	for _, d := range org.dashboards {
+		if *d.Title == *dashboard.Title {
+			writeError(w, http.StatusConflict, "Dashboard with that title already exists.")
+			return
+		}
+	}
+	dashboard.ID = sentry.String(s.newID())
+	dashboard.DateCreated = sentry.Time(time.Now().UTC())
+	org.dashboards = append(org.dashboards, dashboard)
+	writeJSON(w, http.StatusCreated, dashboard)
+}
+
+func (s *Server) getDashboard(w http.ResponseWriter, r *http.Request, v vars) {
+	_, dashboard, ok := s.dashboard(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, dashboard)
+}
+
+func (s *Server) updateDashboard(w http.ResponseWriter, r *http.Request, v vars) {
+	_, dashboard, ok := s.dashboard(w, v)
+	if !ok {
+		return
+	}
+	if !s.saveDashboard(w, r, dashboard) {
+		return
+	}
+	writeJSON(w, http.StatusOK, dashboard)
+}
+
+func (s *Server) deleteDashboard(w http.ResponseWriter, r *http.Request, v vars) {
+	org, dashboard, ok := s.dashboard(w, v)
+	if !ok {
+		return
+	}
+	remove(&org.dashboards, func(d *sentry.Dashboard) bool { return d == dashboard })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Spike protection
+
+func (s *Server) setSpikeProtection(w http.ResponseWriter, r *http.Request, v vars, enabled bool) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.SpikeProtectionParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	var projects []*project
+	if slices.Contains(params.Projects, "$all") {
+		projects = org.projects
+	} else {
+		for _, slug := range params.Projects {
+			p := org.project(slug)
+			if p == nil {
+				writeError(w, http.StatusBadRequest, "projects: Invalid project slug: "+slug)
+				return
+			}
+			projects = append(projects, p)
+		}
+	}
+
+	for _, p := range projects {
+		p.Options[spikeProtectionDisabledOption] = !enabled
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) enableSpikeProtection(w http.ResponseWriter, r *http.Request, v vars) {
+	s.setSpikeProtection(w, r, v, true)
+}
+
+func (s *Server) disableSpikeProtection(w http.ResponseWriter, r *http.Request, v vars) {
+	s.setSpikeProtection(w, r, v, false)
+}
@@ -0,0 +1,969 @@
+package sentrytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryownership"
+)
+
+// spikeProtectionDisabledOption is the project option set by the spike
+// protection endpoints.
+const spikeProtectionDisabledOption = "quotas:spike-protection-disabled"
+
+// filterIDs are the inbound data filters of every project. The legacy
+// browsers filter is active for a list of subfilters, the others are either
+// active or not.
+var filterIDs = []string{
+	"browser-extensions",
+	"localhost",
+	"filtered-transaction",
+	"web-crawlers",
+	"legacy-browsers",
+}
+
+// projectDetails is a project as returned by Sentry, including the settings
+// that go-sentry does not decode.
+type projectDetails struct {
+	sentry.Project
+
+	StoreCrashReports *int                `json:"storeCrashReports"`
+	HighlightTags     []string            `json:"highlightTags"`
+	HighlightContext  map[string][]string `json:"highlightContext"`
+	RelayPiiConfig    *string             `json:"relayPiiConfig"`
+}
+
+type project struct {
+	projectDetails
+
+	teams         []*sentry.Team
+	keys          []*sentry.ProjectKey
+	issueAlerts   []*sentry.IssueAlert
+	metricAlerts  []*sentry.MetricAlert
+	symbolSources []*symbolSource
+	filters       map[string]sentry.BoolOrStringSlice
+	ownership     sentry.ProjectOwnership
+}
+
+type symbolSource struct {
+	ID string
+	sentry.CreateProjectSymbolSourceParams
+}
+
+func (org *organization) project(slug string) *project {
+	for _, p := range org.projects {
+		if p.Slug == slug || p.ID == slug {
+			return p
+		}
+	}
+	return nil
+}
+
+// project returns the project of a request, writing a 404 response if it does
+// not exist.
+func (s *Server) project(w http.ResponseWriter, v vars) (*organization, *project, bool) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+	p := org.project(v["project"])
+	if p == nil {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return nil, nil, false
+	}
+	return org, p, true
+}
+
+func (org *organization) projectResponse(p *project) projectDetails {
+	out := p.projectDetails
+	out.Organization = sentry.Organization{
+		ID:   sentry.String(org.ID),
+		Slug: sentry.String(org.Slug),
+		Name: sentry.String(org.Name),
+	}
+	out.Teams = []sentry.Team{}
+	for _, team := range p.teams {
+		out.Teams = append(out.Teams, org.teamResponse(team))
+	}
+	if len(out.Teams) > 0 {
+		out.Team = out.Teams[0]
+	}
+	return out
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, v vars) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return
+	}
+
+	items := make([]projectDetails, 0, len(org.projects))
+	for _, p := range org.projects {
+		items = append(items, org.projectResponse(p))
+	}
+	writeList(s, w, r, items)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, v vars) {
+	org, team, ok := s.team(w, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.CreateProjectParams
+	if !decode(w, r, &params) {
+		return
+	}
+	if params.Name == "" {
+		writeError(w, http.StatusBadRequest, "name: This field is required.")
+		return
+	}
+	slug := params.Slug
+	if slug == "" {
+		slug = slugify(params.Name)
+	}
+	if org.project(slug) != nil {
+		writeError(w, http.StatusConflict, "A project with this slug already exists.")
+		return
+	}
+
+	p := &project{
+		projectDetails: projectDetails{Project: sentry.Project{
+			ID:               s.newID(),
+			Slug:             slug,
+			Name:             params.Name,
+			Platform:         params.Platform,
+			Color:            "#3f70bf",
+			DateCreated:      time.Now().UTC(),
+			Features:         []string{},
+			Status:           "active",
+			IsMember:         true,
+			HasAccess:        true,
+			Avatar:           sentry.Avatar{Type: "letter_avatar"},
+			Options:          map[string]interface{}{spikeProtectionDisabledOption: false},
+			DigestsMinDelay:  300,
+			DigestsMaxDelay:  1800,
+			ResolveAge:       0,
+			AllowedDomains:   []string{"*"},
+			SafeFields:       []string{},
+			SensitiveFields:  []string{},
+			DataScrubber:     true,
+			ScrapeJavaScript: true,
+			VerifySSL:        false,
+			SecurityToken:    randomHex(32),
+		},
+			HighlightTags:    []string{},
+			HighlightContext: map[string][]string{},
+		},
+		teams:   []*sentry.Team{team},
+		filters: map[string]sentry.BoolOrStringSlice{},
+		ownership: sentry.ProjectOwnership{
+			FallThrough:        true,
+			DateCreated:        time.Now().UTC(),
+			LastUpdated:        time.Now().UTC(),
+			IsActive:           true,
+			AutoAssignment:     "Auto Assign to Issue Owner",
+			CodeownersAutoSync: sentry.Bool(true),
+		},
+	}
+	for _, id := range filterIDs {
+		if id == "legacy-browsers" {
+			p.filters[id] = sentry.BoolOrStringSlice{IsStringSlice: true, StringSliceVal: []string{}}
+		} else {
+			p.filters[id] = sentry.BoolOrStringSlice{IsBool: true}
+		}
+	}
+
+	// Like Sentry, create a client key and an alert rule with each project
+	p.keys = append(p.keys, s.newKey(r, org, p, "Default", nil))
+	if params.DefaultRules == nil || *params.DefaultRules {
+		p.issueAlerts = append(p.issueAlerts, &sentry.IssueAlert{
+			ID:          sentry.String(s.newID()),
+			Name:        sentry.String("Send a notification for high priority issues"),
+			Conditions:  []map[string]interface{}{{"id": "sentry.rules.conditions.high_priority_issue.NewHighPriorityIssueCondition"}},
+			Filters:     []map[string]interface{}{},
+			Actions:     []map[string]interface{}{{"id": "sentry.mail.actions.NotifyEmailAction", "targetType": "IssueOwners", "fallthroughType": "ActiveMembers"}},
+			ActionMatch: sentry.String("any"),
+			FilterMatch: sentry.String("all"),
+			Frequency:   (*json.Number)(sentry.String("30")),
+			DateCreated: sentry.Time(time.Now().UTC()),
+			Projects:    []string{slug},
+		})
+	}
+
+	org.projects = append(org.projects, p)
+	writeJSON(w, http.StatusCreated, org.projectResponse(p))
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, v vars) {
+	org, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, org.projectResponse(p))
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, v vars) {
+	org, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request: "+err.Error())
+		return
+	}
+
+	var params struct {
+		ID             *string         `json:"id"`
+		Slug           *string         `json:"slug"`
+		Organization   json.RawMessage `json:"organization"`
+		Team           json.RawMessage `json:"team"`
+		Teams          json.RawMessage `json:"teams"`
+		RelayPiiConfig *string         `json:"relayPiiConfig"`
+	}
+	if err := json.Unmarshal(body, &params); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request: "+err.Error())
+		return
+	}
+	if params.ID != nil || params.Organization != nil || params.Team != nil || params.Teams != nil {
+		writeError(w, http.StatusBadRequest, "id, organization and teams cannot be updated.")
+		return
+	}
+	if params.Slug != nil && *params.Slug != p.Slug && org.project(*params.Slug) != nil {
+		writeError(w, http.StatusBadRequest, "slug: Another project is already using that slug")
+		return
+	}
+	if !validRelayPiiConfig(params.RelayPiiConfig) {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"relayPiiConfig": {"Invalid JSON value."}})
+		return
+	}
+
+	// Update the fields set in the request only
+	if err := json.Unmarshal(body, &p.projectDetails); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request: "+err.Error())
+		return
+	}
+	for _, alert := range p.issueAlerts {
+		alert.Projects = []string{p.Slug}
+	}
+	for _, alert := range p.metricAlerts {
+		alert.Projects = []string{p.Slug}
+	}
+	writeJSON(w, http.StatusOK, org.projectResponse(p))
+}
+
+// validRelayPiiConfig reports whether the data scrubbing rules of a request
+// are empty or a JSON object, like Sentry requires.
+func validRelayPiiConfig(config *string) bool {
+	if config == nil || *config == "" {
+		return true
+	}
+	var v map[string]interface{}
+	return json.Unmarshal([]byte(*config), &v) == nil
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, v vars) {
+	org, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	remove(&org.projects, func(other *project) bool { return other == p })
+	for _, action := range org.notificationActions {
+		remove(&action.Projects, func(id json.Number) bool { return id.String() == p.ID })
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) addProjectTeam(w http.ResponseWriter, r *http.Request, v vars) {
+	org, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+	team := org.team(v["team"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return
+	}
+
+	if !slices.Contains(p.teams, team) {
+		p.teams = append(p.teams, team)
+	}
+	writeJSON(w, http.StatusCreated, org.projectResponse(p))
+}
+
+func (s *Server) removeProjectTeam(w http.ResponseWriter, r *http.Request, v vars) {
+	org, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+	team := org.team(v["team"])
+	if team == nil {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return
+	}
+
+	remove(&p.teams, func(t *sentry.Team) bool { return t == team })
+	writeJSON(w, http.StatusOK, org.projectResponse(p))
+}
+
+// Client keys
+
+func (s *Server) newKey(r *http.Request, org *organization, p *project, name string, rateLimit *sentry.ProjectKeyRateLimit) *sentry.ProjectKey {
+	public := randomHex(16)
+	secret := randomHex(16)
+	host := fmt.Sprintf("o%s.ingest.%s", org.ID, r.Host)
+
+	return &sentry.ProjectKey{
+		ID:        public,
+		Name:      name,
+		Label:     name,
+		Public:    public,
+		Secret:    secret,
+		ProjectID: json.Number(p.ID),
+		IsActive:  true,
+		RateLimit: rateLimit,
+		DSN: sentry.ProjectKeyDSN{
+			Secret:   fmt.Sprintf("http://%s:%s@%s/%s", public, secret, host, p.ID),
+			Public:   fmt.Sprintf("http://%s@%s/%s", public, host, p.ID),
+			CSP:      fmt.Sprintf("http://%s/api/%s/csp-report/?sentry_key=%s", host, p.ID, public),
+			Security: fmt.Sprintf("http://%s/api/%s/security/?sentry_key=%s", host, p.ID, public),
+			Minidump: fmt.Sprintf("http://%s/api/%s/minidump/?sentry_key=%s", host, p.ID, public),
+			NEL:      fmt.Sprintf("http://%s/api/%s/nel/?sentry_key=%s", host, p.ID, public),
+			Unreal:   fmt.Sprintf("http://%s/api/%s/unreal/%s/", host, p.ID, public),
+			CDN:      fmt.Sprintf("http://%s/js-sdk-loader/%s.min.js", r.Host, public),
+			Crons:    fmt.Sprintf("http://%s/api/%s/cron/___MONITOR_SLUG___/%s/", host, p.ID, public),
+		},
+		BrowserSDKVersion: "7.x",
+		DateCreated:       time.Now().UTC(),
+	}
+}
+
+func (s *Server) key(w http.ResponseWriter, v vars) (*project, *sentry.ProjectKey, bool) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+	for _, key := range p.keys {
+		if key.ID == v["key"] {
+			return p, key, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested resource does not exist")
+	return nil, nil, false
+}
+
+func (s *Server) listKeys(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	status := r.URL.Query().Get("status")
+	items := []*sentry.ProjectKey{}
+	for _, key := range p.keys {
+		if status == "" || (status == "active") == key.IsActive {
+			items = append(items, key)
+		}
+	}
+	writeList(s, w, r, items)
+}
+
+func (s *Server) createKey(w http.ResponseWriter, r *http.Request, v vars) {
+	org, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.CreateProjectKeyParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	key := s.newKey(r, org, p, params.Name, params.RateLimit)
+	p.keys = append(p.keys, key)
+	writeJSON(w, http.StatusCreated, key)
+}
+
+func (s *Server) getKey(w http.ResponseWriter, r *http.Request, v vars) {
+	_, key, ok := s.key(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, key)
+}
+
+func (s *Server) updateKey(w http.ResponseWriter, r *http.Request, v vars) {
+	_, key, ok := s.key(w, v)
+	if !ok {
+		return
+	}
+
+	var params struct {
+		Name                    *string                                   `json:"name"`
+		IsActive                *bool                                     `json:"isActive"`
+		RateLimit               *sentry.ProjectKeyRateLimit               `json:"rateLimit"`
+		DynamicSDKLoaderOptions *sentry.ProjectKeyDynamicSDKLoaderOptions `json:"dynamicSdkLoaderOptions"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+	if params.Name != nil {
+		key.Name = *params.Name
+		key.Label = *params.Name
+	}
+	if params.IsActive != nil {
+		key.IsActive = *params.IsActive
+	}
+	key.RateLimit = params.RateLimit
+	if params.DynamicSDKLoaderOptions != nil {
+		key.DynamicSDKLoaderOptions = *params.DynamicSDKLoaderOptions
+	}
+	writeJSON(w, http.StatusOK, key)
+}
+
+func (s *Server) deleteKey(w http.ResponseWriter, r *http.Request, v vars) {
+	p, key, ok := s.key(w, v)
+	if !ok {
+		return
+	}
+	remove(&p.keys, func(k *sentry.ProjectKey) bool { return k == key })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Issue alerts
+
+func (s *Server) issueAlert(w http.ResponseWriter, v vars) (*project, *sentry.IssueAlert, bool) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+	for _, alert := range p.issueAlerts {
+		if *alert.ID == v["rule"] {
+			return p, alert, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested resource does not exist")
+	return nil, nil, false
+}
+
+// saveIssueAlert decodes the request into alert.
+func (s *Server) saveIssueAlert(w http.ResponseWriter, r *http.Request, p *project, alert *sentry.IssueAlert) bool {
+	var params sentry.IssueAlert
+	if !decode(w, r, &params) {
+		return false
+	}
+	if params.Name == nil || *params.Name == "" {
+		writeError(w, http.StatusBadRequest, "name: This field is required.")
+		return false
+	}
+	if params.ActionMatch == nil {
+		writeError(w, http.StatusBadRequest, "actionMatch: This field is required.")
+		return false
+	}
+	if len(params.Actions) == 0 {
+		writeError(w, http.StatusBadRequest, "actions: You must add an action for this alert to fire.")
+		return false
+	}
+
+	*alert = sentry.IssueAlert{
+		ID:          alert.ID,
+		Conditions:  params.Conditions,
+		Filters:     params.Filters,
+		Actions:     params.Actions,
+		ActionMatch: params.ActionMatch,
+		FilterMatch: params.FilterMatch,
+		Frequency:   params.Frequency,
+		Name:        params.Name,
+		DateCreated: alert.DateCreated,
+		Owner:       params.Owner,
+		Environment: params.Environment,
+		Projects:    []string{p.Slug},
+	}
+	if alert.Conditions == nil {
+		alert.Conditions = []map[string]interface{}{}
+	}
+	if alert.Filters == nil {
+		alert.Filters = []map[string]interface{}{}
+	}
+	if alert.FilterMatch == nil {
+		alert.FilterMatch = sentry.String("all")
+	}
+	if alert.Frequency == nil {
+		alert.Frequency = (*json.Number)(sentry.String("30"))
+	}
+	return true
+}
+
+func (s *Server) listIssueAlerts(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+	writeList(s, w, r, p.issueAlerts)
+}
+
+func (s *Server) createIssueAlert(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	alert := &sentry.IssueAlert{
+		ID:          sentry.String(s.newID()),
+		DateCreated: sentry.Time(time.Now().UTC()),
+	}
+	if !s.saveIssueAlert(w, r, p, alert) {
+		return
+	}
+	p.issueAlerts = append(p.issueAlerts, alert)
+	writeJSON(w, http.StatusCreated, alert)
+}
+
+func (s *Server) getIssueAlert(w http.ResponseWriter, r *http.Request, v vars) {
+	_, alert, ok := s.issueAlert(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) updateIssueAlert(w http.ResponseWriter, r *http.Request, v vars) {
+	p, alert, ok := s.issueAlert(w, v)
+	if !ok {
+		return
+	}
+	if !s.saveIssueAlert(w, r, p, alert) {
+		return
+	}
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) deleteIssueAlert(w http.ResponseWriter, r *http.Request, v vars) {
+	p, alert, ok := s.issueAlert(w, v)
+	if !ok {
+		return
+	}
+	remove(&p.issueAlerts, func(a *sentry.IssueAlert) bool { return a == alert })
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// Metric alerts
+
+// metricAlert returns the metric alert of a request, looked up in the
+// project of the request or, for organization routes, in all projects.
+func (s *Server) metricAlert(w http.ResponseWriter, v vars) (*project, *sentry.MetricAlert, bool) {
+	org, ok := s.organization(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+
+	for _, p := range org.projects {
+		if slug, ok := v["project"]; ok && p.Slug != slug {
+			continue
+		}
+		for _, alert := range p.metricAlerts {
+			if *alert.ID == v["rule"] {
+				return p, alert, true
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested resource does not exist")
+	return nil, nil, false
+}
+
+// saveMetricAlert decodes the request into alert, assigning IDs to the new
+// triggers and actions.
+func (s *Server) saveMetricAlert(w http.ResponseWriter, r *http.Request, p *project, alert *sentry.MetricAlert) bool {
+	var params sentry.MetricAlert
+	if !decode(w, r, &params) {
+		return false
+	}
+	if params.Name == nil || *params.Name == "" {
+		writeError(w, http.StatusBadRequest, "name: This field is required.")
+		return false
+	}
+	if params.Aggregate == nil || params.TimeWindow == nil {
+		writeError(w, http.StatusBadRequest, "aggregate and timeWindow are required.")
+		return false
+	}
+	if len(params.Triggers) == 0 {
+		writeError(w, http.StatusBadRequest, "triggers: Must include at least one trigger.")
+		return false
+	}
+
+	params.ID = alert.ID
+	params.DateCreated = alert.DateCreated
+	params.Projects = []string{p.Slug}
+	params.TaskUUID = nil
+	if params.Query == nil {
+		params.Query = sentry.String("")
+	}
+	if params.DataSet == nil {
+		params.DataSet = sentry.String("events")
+	}
+	for _, trigger := range params.Triggers {
+		if trigger.ID == nil {
+			trigger.ID = sentry.String(s.newID())
+			trigger.DateCreated = sentry.Time(time.Now().UTC())
+		}
+		trigger.AlertRuleID = alert.ID
+		if trigger.Actions == nil {
+			trigger.Actions = []*sentry.MetricAlertTriggerAction{}
+		}
+		for _, action := range trigger.Actions {
+			if action.ID == nil {
+				action.ID = sentry.String(s.newID())
+				action.DateCreated = sentry.Time(time.Now().UTC())
+			}
+			action.AlertRuleTriggerID = trigger.ID
+		}
+	}
+
+	*alert = params
+	return true
+}
+
+func (s *Server) listMetricAlerts(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+	writeList(s, w, r, p.metricAlerts)
+}
+
+func (s *Server) createMetricAlert(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	alert := &sentry.MetricAlert{
+		ID:          sentry.String(s.newID()),
+		DateCreated: sentry.Time(time.Now().UTC()),
+	}
+	if !s.saveMetricAlert(w, r, p, alert) {
+		return
+	}
+	p.metricAlerts = append(p.metricAlerts, alert)
+	writeJSON(w, http.StatusCreated, alert)
+}
+
+func (s *Server) getMetricAlert(w http.ResponseWriter, r *http.Request, v vars) {
+	_, alert, ok := s.metricAlert(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) updateMetricAlert(w http.ResponseWriter, r *http.Request, v vars) {
+	p, alert, ok := s.metricAlert(w, v)
+	if !ok {
+		return
+	}
+	if !s.saveMetricAlert(w, r, p, alert) {
+		return
+	}
+	writeJSON(w, http.StatusOK, alert)
+}
+
+func (s *Server) deleteMetricAlert(w http.ResponseWriter, r *http.Request, v vars) {
+	p, alert, ok := s.metricAlert(w, v)
+	if !ok {
+		return
+	}
+	remove(&p.metricAlerts, func(a *sentry.MetricAlert) bool { return a == alert })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Symbol sources
+
+func (src *symbolSource) response() *sentry.ProjectSymbolSource {
+	hidden := func(v *string) *sentry.ProjectSymbolSourceHiddenSecret {
+		if v == nil {
+			return nil
+		}
+		return &sentry.ProjectSymbolSourceHiddenSecret{HiddenSecret: sentry.Bool(true)}
+	}
+
+	return &sentry.ProjectSymbolSource{
+		ID:                   sentry.String(src.ID),
+		Type:                 src.Type,
+		Name:                 src.Name,
+		Layout:               src.Layout,
+		AppConnectIssuer:     src.AppConnectIssuer,
+		AppConnectPrivateKey: hidden(src.AppConnectPrivateKey),
+		AppId:                src.AppId,
+		Url:                  src.Url,
+		Username:             src.Username,
+		Password:             hidden(src.Password),
+		Bucket:               src.Bucket,
+		Region:               src.Region,
+		AccessKey:            src.AccessKey,
+		SecretKey:            hidden(src.SecretKey),
+		Prefix:               src.Prefix,
+		ClientEmail:          src.ClientEmail,
+		PrivateKey:           hidden(src.PrivateKey),
+	}
+}
+
+func validSymbolSource(w http.ResponseWriter, params *sentry.CreateProjectSymbolSourceParams) bool {
+	if params.Type == nil || !slices.Contains([]string{"http", "gcs", "s3", "appStoreConnect"}, *params.Type) {
+		writeError(w, http.StatusBadRequest, "type: Invalid source type.")
+		return false
+	}
+	if params.Name == nil || *params.Name == "" {
+		writeError(w, http.StatusBadRequest, "name: This field is required.")
+		return false
+	}
+	return true
+}
+
+func (s *Server) symbolSource(w http.ResponseWriter, r *http.Request, v vars) (*project, *symbolSource, bool) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return nil, nil, false
+	}
+	id := r.URL.Query().Get("id")
+	for _, src := range p.symbolSources {
+		if src.ID == id {
+			return p, src, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "The requested source does not exist")
+	return nil, nil, false
+}
+
+func (s *Server) listSymbolSources(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	items := []*sentry.ProjectSymbolSource{}
+	for _, src := range p.symbolSources {
+		if id == "" || src.ID == id {
+			items = append(items, src.response())
+		}
+	}
+	if id != "" && len(items) == 0 {
+		writeError(w, http.StatusNotFound, "The requested source does not exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) createSymbolSource(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	src := &symbolSource{ID: randomHex(16)}
+	if !decode(w, r, &src.CreateProjectSymbolSourceParams) || !validSymbolSource(w, &src.CreateProjectSymbolSourceParams) {
+		return
+	}
+	p.symbolSources = append(p.symbolSources, src)
+	writeJSON(w, http.StatusCreated, src.response())
+}
+
+func (s *Server) updateSymbolSource(w http.ResponseWriter, r *http.Request, v vars) {
+	_, src, ok := s.symbolSource(w, r, v)
+	if !ok {
+		return
+	}
+
+	var params sentry.UpdateProjectSymbolSourceParams
+	if !decode(w, r, &params) {
+		return
+	}
+	updated := sentry.CreateProjectSymbolSourceParams{
+		Type:                 params.Type,
+		Name:                 params.Name,
+		Layout:               params.Layout,
+		AppConnectIssuer:     params.AppConnectIssuer,
+		AppConnectPrivateKey: params.AppConnectPrivateKey,
+		AppId:                params.AppId,
+		Url:                  params.Url,
+		Username:             params.Username,
+		Password:             params.Password,
+		Bucket:               params.Bucket,
+		Region:               params.Region,
+		AccessKey:            params.AccessKey,
+		SecretKey:            params.SecretKey,
+		Prefix:               params.Prefix,
+		ClientEmail:          params.ClientEmail,
+		PrivateKey:           params.PrivateKey,
+	}
+	if !validSymbolSource(w, &updated) {
+		return
+	}
+
+	// Like Sentry, keep the secrets that are not set
+	if updated.Password == nil {
+		updated.Password = src.Password
+	}
+	if updated.SecretKey == nil {
+		updated.SecretKey = src.SecretKey
+	}
+	if updated.PrivateKey == nil {
+		updated.PrivateKey = src.PrivateKey
+	}
+	if updated.AppConnectPrivateKey == nil {
+		updated.AppConnectPrivateKey = src.AppConnectPrivateKey
+	}
+	src.CreateProjectSymbolSourceParams = updated
+	writeJSON(w, http.StatusOK, src.response())
+}
+
+func (s *Server) deleteSymbolSource(w http.ResponseWriter, r *http.Request, v vars) {
+	p, src, ok := s.symbolSource(w, r, v)
+	if !ok {
+		return
+	}
+	remove(&p.symbolSources, func(other *symbolSource) bool { return other == src })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Inbound data filters
+
+func (s *Server) listFilters(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	items := make([]sentry.ProjectInboundDataFilter, 0, len(filterIDs))
+	for _, id := range filterIDs {
+		items = append(items, sentry.ProjectInboundDataFilter{ID: id, Active: p.filters[id]})
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) updateFilter(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	id := v["filter"]
+	if !slices.Contains(filterIDs, id) {
+		writeError(w, http.StatusNotFound, "The requested resource does not exist")
+		return
+	}
+
+	var params sentry.UpdateProjectInboundDataFilterParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if id == "legacy-browsers" {
+		subfilters := params.Subfilters
+		if subfilters == nil {
+			subfilters = []string{}
+		}
+		p.filters[id] = sentry.BoolOrStringSlice{IsStringSlice: true, StringSliceVal: subfilters}
+	} else {
+		if params.Active == nil {
+			writeError(w, http.StatusBadRequest, "active: This field is required.")
+			return
+		}
+		p.filters[id] = sentry.BoolOrStringSlice{IsBool: true, BoolVal: *params.Active}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Ownership
+
+func (s *Server) getProjectOwnership(w http.ResponseWriter, r *http.Request, v vars) {
+	_, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, p.ownership)
+}
+
+func (s *Server) updateProjectOwnership(w http.ResponseWriter, r *http.Request, v vars) {
+	org, p, ok := s.project(w, v)
+	if !ok {
+		return
+	}
+
+	var params struct {
+		Raw                *string `json:"raw"`
+		FallThrough        *bool   `json:"fallthrough"`
+		AutoAssignment     *string `json:"autoAssignment"`
+		CodeownersAutoSync *bool   `json:"codeownersAutoSync"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if params.Raw != nil {
+		rules, err := sentryownership.Parse(*params.Raw)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"raw": {"Parse error: " + err.Error()}})
+			return
+		}
+		// Like Sentry, reject the owners that are not teams or members
+		var invalid []string
+		for _, rule := range rules {
+			for _, owner := range rule.Owners {
+				if !org.hasOwner(owner) && !slices.Contains(invalid, owner) {
+					invalid = append(invalid, owner)
+				}
+			}
+		}
+		if len(invalid) > 0 {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"raw": {"Invalid rule owners: " + strings.Join(invalid, ", ")}})
+			return
+		}
+		p.ownership.Raw = *params.Raw
+	}
+	if params.AutoAssignment != nil {
+		if !slices.Contains(autoAssignmentValues, *params.AutoAssignment) {
+			writeJSON(w, http.StatusBadRequest, map[string][]string{"autoAssignment": {"Invalid value."}})
+			return
+		}
+		p.ownership.AutoAssignment = *params.AutoAssignment
+	}
+	if params.FallThrough != nil {
+		p.ownership.FallThrough = *params.FallThrough
+	}
+	if params.CodeownersAutoSync != nil {
+		p.ownership.CodeownersAutoSync = params.CodeownersAutoSync
+	}
+	p.ownership.LastUpdated = time.Now().UTC()
+	writeJSON(w, http.StatusOK, p.ownership)
+}
+
+// autoAssignmentValues are the auto-assignment settings of project ownership.
+var autoAssignmentValues = []string{
+	"Auto Assign to Suspect Commits",
+	"Auto Assign to Issue Owner",
+	"Turn off Auto-Assignment",
+}
+
+// hasOwner reports whether owner is a team or the email of a member of the
+// organization.
+func (org *organization) hasOwner(owner string) bool {
+	if sentryownership.IsTeam(owner) {
+		return org.team(strings.TrimPrefix(owner, "#")) != nil
+	}
+	for _, member := range org.members {
+		if strings.EqualFold(member.Email, owner) || strings.EqualFold(member.User.Email, owner) {
+			return true
+		}
+	}
+	return false
+}
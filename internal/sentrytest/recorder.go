@@ -0,0 +1,378 @@
+package sentrytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RecorderMode is the mode of a Recorder.
+type RecorderMode int
+
+const (
+	// ModeRecord sends the requests and records them to the cassette.
+	ModeRecord RecorderMode = iota
+	// ModeReplay serves the requests from the cassette without sending them.
+	ModeReplay
+)
+
+// recordedHeaders are the response headers kept in cassettes.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Link",
+	"X-Sentry-Request-Id",
+}
+
+// secretFields are the JSON fields of responses whose string values are
+// redacted from cassettes, such as the keys making up DSNs.
+var secretFields = map[string]struct{}{
+	"public": {},
+	"secret": {},
+}
+
+// minSecretLength is the minimum length of the secret field values redacted,
+// so that short values do not redact unrelated parts of the interactions.
+const minSecretLength = 16
+
+// Cassette is a recording of the HTTP interactions of a test.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  InteractionRequest  `json:"request"`
+	Response InteractionResponse `json:"response"`
+}
+
+// InteractionRequest is a recorded request. The URL is made of the path and
+// the query, so recordings do not depend on the API host.
+type InteractionRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// InteractionResponse is a recorded response.
+type InteractionResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+type replacement struct {
+	value       string
+	placeholder string
+}
+
+// Recorder records the HTTP interactions of a test to a cassette file, or
+// replays them from it.
+//
+// Values registered with Replace, such as randomly generated names, are
+// stored as placeholders, so the cassette can be replayed by a run generating
+// different values. Values registered with Redact, the values of the secret
+// fields of responses and the request headers are never stored. Rate limited
+// responses are not recorded.
+type Recorder struct {
+	mode RecorderMode
+	path string
+
+	mu           sync.Mutex
+	cassette     Cassette
+	used         []bool
+	replacements []replacement
+	redactions   []replacement
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In replay mode,
+// the cassette is read immediately, and an error wrapping fs.ErrNotExist is
+// returned if it does not exist.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{
+		mode: mode,
+		path: path,
+	}
+
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() RecorderMode {
+	return r.mode
+}
+
+// Replace stores value as placeholder in the cassette. In replay mode,
+// value is replaced with placeholder in the requests before they are matched,
+// and placeholder with value in the responses.
+func (r *Recorder) Replace(value string, placeholder string) {
+	if value == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.replacements = append(r.replacements, replacement{value: value, placeholder: placeholder})
+}
+
+// Redact removes value from the recorded interactions. It has no effect in
+// replay mode.
+func (r *Recorder) Redact(value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.redact(value)
+}
+
+func (r *Recorder) redact(value string) {
+	if value == "" || r.mode != ModeRecord {
+		return
+	}
+	for _, redaction := range r.redactions {
+		if redaction.value == value {
+			return
+		}
+	}
+
+	// Keep the length and alphabet of keys, so that replayed DSNs stay valid
+	n := strconv.FormatInt(int64(len(r.redactions)+1), 16)
+	placeholder := strings.Repeat("0", max(len(value)-len(n), 0)) + n
+	r.redactions = append(r.redactions, replacement{value: value, placeholder: placeholder})
+}
+
+// Wrap returns a transport recording the requests sent through next, or
+// replaying them without calling next.
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if r.mode == ModeReplay {
+			return r.replay(req)
+		}
+		return r.record(req, next)
+	})
+}
+
+// Save writes the recorded interactions to the cassette file. It has no
+// effect in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+	reqBody, err := readAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if reqBody != nil {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return resp, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.redactSecretFields(respBody)
+	scrub := r.replacer(false)
+
+	header := http.Header{}
+	for _, key := range recordedHeaders {
+		for _, v := range resp.Header.Values(key) {
+			header.Add(key, scrub.Replace(v))
+		}
+	}
+
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: InteractionRequest{
+			Method: req.Method,
+			URL:    scrub.Replace(req.URL.RequestURI()),
+			Body:   scrub.Replace(string(reqBody)),
+		},
+		Response: InteractionResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       scrub.Replace(string(respBody)),
+		},
+	})
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	reqBody, err := readAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	scrub := r.replacer(false)
+	want := InteractionRequest{
+		Method: req.Method,
+		URL:    scrub.Replace(req.URL.RequestURI()),
+		Body:   scrub.Replace(string(reqBody)),
+	}
+
+	// Each request is served by the first unused interaction recorded for the
+	// same request, so concurrent requests may be sent in any order. Reads
+	// sent more often than recorded, for example because of caching, are
+	// served by the last matching interaction.
+	var interaction *Interaction
+	last := -1
+	for i, candidate := range r.cassette.Interactions {
+		if candidate.Request != want {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			interaction = candidate
+			break
+		}
+		last = i
+	}
+	if interaction == nil && last >= 0 && req.Method == http.MethodGet {
+		interaction = r.cassette.Interactions[last]
+	}
+	if interaction == nil {
+		return nil, fmt.Errorf("no recorded interaction in %s matches %s %s", r.path, want.Method, want.URL)
+	}
+
+	unscrub := r.replacer(true)
+	header := http.Header{}
+	for key, values := range interaction.Response.Header {
+		for _, v := range values {
+			header.Add(key, unscrub.Replace(v))
+		}
+	}
+	body := unscrub.Replace(interaction.Response.Body)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// redactSecretFields redacts the string values of the secret fields found in
+// a JSON body.
+func (r *Recorder) redactSecretFields(body []byte) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return
+	}
+
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, value := range v {
+				if s, ok := value.(string); ok {
+					if _, ok := secretFields[key]; ok && len(s) >= minSecretLength {
+						r.redact(s)
+					}
+					continue
+				}
+				walk(value)
+			}
+		case []interface{}:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+	walk(v)
+}
+
+// replacer returns a replacer from the values to their placeholders, or the
+// reverse. Longer strings are replaced first, so that a value containing
+// another is not partially replaced.
+func (r *Recorder) replacer(reverse bool) *strings.Replacer {
+	replacements := append(slices.Clone(r.replacements), r.redactions...)
+	from := func(r replacement) string {
+		if reverse {
+			return r.placeholder
+		}
+		return r.value
+	}
+	slices.SortStableFunc(replacements, func(a, b replacement) int {
+		return len(from(b)) - len(from(a))
+	})
+
+	var oldnew []string
+	for _, replacement := range replacements {
+		if reverse {
+			oldnew = append(oldnew, replacement.placeholder, replacement.value)
+		} else {
+			oldnew = append(oldnew, replacement.value, replacement.placeholder)
+		}
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// readAll reads and closes a request or response body, which may be nil.
+func readAll(body io.ReadCloser) ([]byte, error) {
+	if body == nil || body == http.NoBody {
+		return nil, nil
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,119 @@
+package sentrytest
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func newRecorderClient(t *testing.T, r *Recorder, baseURL string) *sentry.Client {
+	t.Helper()
+
+	client, err := sentry.NewOnPremiseClient(baseURL, &http.Client{Transport: r.Wrap(http.DefaultTransport)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return client
+}
+
+// createTeamAndKey creates a team named name with a project and returns the
+// public key of the default key of the project.
+func createTeamAndKey(t *testing.T, client *sentry.Client, name string) string {
+	t.Helper()
+
+	ctx := context.Background()
+	if _, _, err := client.Teams.Create(ctx, DefaultOrganization, &sentry.CreateTeamParams{Slug: sentry.String(name)}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, _, err := client.Projects.Create(ctx, DefaultOrganization, name, &sentry.CreateProjectParams{Name: name}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	keys, _, err := client.ProjectKeys.List(ctx, DefaultOrganization, name, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	key, _, err := client.ProjectKeys.Get(ctx, DefaultOrganization, name, keys[0].ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, _, err := client.Teams.Get(ctx, DefaultOrganization, name); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return key.Public
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "TestRecorder.json")
+
+	s := NewServer()
+	baseURL := s.BaseURL()
+
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	recorder.Replace("tf-team-123456789", "tf-team-random-1")
+	recordedKey := createTeamAndKey(t, newRecorderClient(t, recorder, baseURL), "tf-team-123456789")
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if strings.Contains(string(data), "tf-team-123456789") {
+		t.Errorf("cassette contains the random name")
+	}
+	if strings.Contains(string(data), recordedKey) {
+		t.Errorf("cassette contains the DSN key")
+	}
+	if !strings.Contains(string(data), "tf-team-random-1") {
+		t.Errorf("cassette does not contain the placeholder")
+	}
+
+	// Replay with a different random name, without the server
+	s.Close()
+
+	recorder, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	recorder.Replace("tf-team-987654321", "tf-team-random-1")
+	client := newRecorderClient(t, recorder, baseURL)
+	replayedKey := createTeamAndKey(t, client, "tf-team-987654321")
+	if len(replayedKey) != len(recordedKey) || replayedKey == recordedKey {
+		t.Errorf("got key %q, want a placeholder of length %d", replayedKey, len(recordedKey))
+	}
+
+	// Reads can be replayed again
+	team, _, err := client.Teams.Get(context.Background(), DefaultOrganization, "tf-team-987654321")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *team.Slug != "tf-team-987654321" {
+		t.Errorf("got slug %q, want tf-team-987654321", *team.Slug)
+	}
+
+	// Writes cannot
+	_, _, err = client.Teams.Create(context.Background(), DefaultOrganization, &sentry.CreateTeamParams{Slug: sentry.String("tf-team-987654321")})
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("got %v, want no recorded interaction error", err)
+	}
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	if !os.IsNotExist(err) {
+		t.Errorf("got %v, want not exist error", err)
+	}
+}
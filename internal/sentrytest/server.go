@@ -0,0 +1,448 @@
+// Package sentrytest provides a stateful, in-process fake of the Sentry API
+// endpoints used by the provider, so that tests can run offline.
+package sentrytest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/pkg/must"
+)
+
+const (
+	// DefaultOrganization is the slug of the organization every server starts
+	// with.
+	DefaultOrganization = "terraform-provider-sentry"
+
+	// DefaultPageSize is the default number of items in each page of a list.
+	DefaultPageSize = 100
+
+	// DefaultRateLimit is the default number of requests allowed per second.
+	DefaultRateLimit = 1000
+
+	// DefaultConcurrentLimit is the default number of concurrent requests
+	// reported in the rate limit headers.
+	DefaultConcurrentLimit = 25
+)
+
+// AllScopes are the scopes of the token accepted by default.
+var AllScopes = []string{
+	"alerts:read", "alerts:write",
+	"event:admin", "event:read", "event:write",
+	"member:admin", "member:read", "member:write",
+	"org:admin", "org:integrations", "org:read", "org:write",
+	"project:admin", "project:read", "project:releases", "project:write",
+	"team:admin", "team:read", "team:write",
+}
+
+// Server is a fake Sentry API server. It stores the organizations, teams,
+// projects and other objects created through it in memory.
+//
+// The fields must not be changed once the server is started.
+type Server struct {
+	*httptest.Server
+
+	// Token, if set, is the only token accepted. Otherwise any token is.
+	Token string
+	// Scopes are the scopes of the token, as reported by the API root.
+	// Defaults to AllScopes.
+	Scopes []string
+
+	// PageSize is the number of items in each page of a list. Defaults to
+	// DefaultPageSize.
+	PageSize int
+	// RateLimit is the number of requests allowed per second, after which
+	// requests are rejected with 429 Too Many Requests. Defaults to
+	// DefaultRateLimit.
+	RateLimit int
+	// ConcurrentLimit is the concurrency limit reported in the rate limit
+	// headers. Defaults to DefaultConcurrentLimit.
+	ConcurrentLimit int
+
+	routes []route
+
+	inFlight atomic.Int32
+
+	mu             sync.Mutex
+	lastID         int
+	window         int64
+	windowRequests int
+	organizations  []*organization
+}
+
+// NewServer starts and returns a new Server with the default organization.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := NewUnstartedServer()
+	s.Start()
+	return s
+}
+
+// NewUnstartedServer returns a new Server with the default organization, that
+// is not started yet, so that its fields can be changed. The caller should
+// call Start when done.
+func NewUnstartedServer() *Server {
+	s := &Server{
+		Scopes:          AllScopes,
+		PageSize:        DefaultPageSize,
+		RateLimit:       DefaultRateLimit,
+		ConcurrentLimit: DefaultConcurrentLimit,
+	}
+	s.Server = httptest.NewUnstartedServer(s)
+	s.routes = s.newRoutes()
+	s.AddOrganization(DefaultOrganization)
+	return s
+}
+
+// BaseURL returns the base API URL of the server, to be used as the provider
+// `base_url`.
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/"
+}
+
+// AddOrganization adds an organization and returns its ID. Its owner is a
+// member named after the organization.
+func (s *Server) AddOrganization(slug string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addOrganization(slug, slug).ID
+}
+
+// AddIntegration installs an integration of the provider with the given key,
+// e.g. `pagerduty`, in an organization, and returns its ID. Integrations
+// cannot be installed through the API.
+func (s *Server) AddIntegration(organizationSlug string, providerKey string, name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	org := s.findOrganization(organizationSlug)
+	if org == nil {
+		panic(fmt.Sprintf("sentrytest: organization %q does not exist", organizationSlug))
+	}
+
+	integration := &sentry.OrganizationIntegration{
+		ID:         s.newID(),
+		Name:       name,
+		DomainName: name,
+		Status:     "active",
+		Provider: sentry.OrganizationIntegrationProvider{
+			Key:        providerKey,
+			Slug:       providerKey,
+			Name:       providerKey,
+			CanAdd:     true,
+			CanDisable: false,
+			Features:   []string{"alert-rule", "incident-management"},
+		},
+		ConfigData:                    json.RawMessage(`{}`),
+		ExternalId:                    name,
+		OrganizationId:                must.Get(strconv.Atoi(org.ID)),
+		OrganizationIntegrationStatus: "active",
+	}
+	org.integrations = append(org.integrations, integration)
+	return integration.ID
+}
+
+func (s *Server) newID() string {
+	s.lastID++
+	return strconv.Itoa(s.lastID)
+}
+
+func (s *Server) findOrganization(slug string) *organization {
+	for _, org := range s.organizations {
+		if org.Slug == slug || org.ID == slug {
+			return org
+		}
+	}
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	inFlight := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+
+	if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "Invalid token")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Rate limits
+	now := time.Now()
+	if window := now.Unix(); window != s.window {
+		s.window = window
+		s.windowRequests = 0
+	}
+	s.windowRequests++
+
+	h := w.Header()
+	h.Set("X-Sentry-Rate-Limit-Limit", strconv.Itoa(s.RateLimit))
+	h.Set("X-Sentry-Rate-Limit-Remaining", strconv.Itoa(max(s.RateLimit-s.windowRequests, 0)))
+	h.Set("X-Sentry-Rate-Limit-Reset", strconv.FormatInt(s.window+1, 10))
+	h.Set("X-Sentry-Rate-Limit-ConcurrentLimit", strconv.Itoa(s.ConcurrentLimit))
+	h.Set("X-Sentry-Rate-Limit-ConcurrentRemaining", strconv.Itoa(max(s.ConcurrentLimit-int(inFlight), 0)))
+	h.Set("X-Sentry-Request-Id", randomHex(16))
+
+	if s.windowRequests > s.RateLimit {
+		writeError(w, http.StatusTooManyRequests, "You are attempting to use this endpoint too frequently. Limit is "+strconv.Itoa(s.RateLimit)+" requests in 1 seconds")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/")
+	methodAllowed := false
+	for _, route := range s.routes {
+		vars, ok := route.match(path)
+		if !ok {
+			continue
+		}
+		if route.method != r.Method {
+			methodAllowed = true
+			continue
+		}
+
+		route.handle(w, r, vars)
+		return
+	}
+
+	if methodAllowed {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %q not allowed.", r.Method))
+		return
+	}
+	writeError(w, http.StatusNotFound, "The requested resource does not exist")
+}
+
+// vars are the variables of a matched route pattern.
+type vars map[string]string
+
+type route struct {
+	method  string
+	pattern []string
+	handle  func(http.ResponseWriter, *http.Request, vars)
+}
+
+func newRoute(method string, pattern string, handle func(http.ResponseWriter, *http.Request, vars)) route {
+	return route{
+		method:  method,
+		pattern: strings.Split(pattern, "/"),
+		handle:  handle,
+	}
+}
+
+func (rt route) match(path string) (vars, bool) {
+	parts := strings.Split(path, "/")
+	if len(parts) != len(rt.pattern) {
+		return nil, false
+	}
+
+	v := vars{}
+	for i, p := range rt.pattern {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			if parts[i] == "" {
+				return nil, false
+			}
+			v[p[1:len(p)-1]], _ = url.PathUnescape(parts[i])
+		} else if p != parts[i] {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func (s *Server) newRoutes() []route {
+	return []route{
+		newRoute(http.MethodGet, "0/", s.getRoot),
+
+		newRoute(http.MethodGet, "0/organizations/", s.listOrganizations),
+		newRoute(http.MethodPost, "0/organizations/", s.createOrganization),
+		newRoute(http.MethodGet, "0/organizations/{org}/", s.getOrganization),
+		newRoute(http.MethodPut, "0/organizations/{org}/", s.updateOrganization),
+		newRoute(http.MethodDelete, "0/organizations/{org}/", s.deleteOrganization),
+
+		newRoute(http.MethodGet, "0/organizations/{org}/teams/", s.listTeams),
+		newRoute(http.MethodPost, "0/organizations/{org}/teams/", s.createTeam),
+		newRoute(http.MethodGet, "0/teams/{org}/{team}/", s.getTeam),
+		newRoute(http.MethodPut, "0/teams/{org}/{team}/", s.updateTeam),
+		newRoute(http.MethodDelete, "0/teams/{org}/{team}/", s.deleteTeam),
+
+		newRoute(http.MethodGet, "0/organizations/{org}/members/", s.listMembers),
+		newRoute(http.MethodPost, "0/organizations/{org}/members/", s.createMember),
+		newRoute(http.MethodGet, "0/organizations/{org}/members/{member}/", s.getMember),
+		newRoute(http.MethodPut, "0/organizations/{org}/members/{member}/", s.updateMember),
+		newRoute(http.MethodDelete, "0/organizations/{org}/members/{member}/", s.deleteMember),
+		newRoute(http.MethodPost, "0/organizations/{org}/members/{member}/teams/{team}/", s.addTeamMember),
+		newRoute(http.MethodPut, "0/organizations/{org}/members/{member}/teams/{team}/", s.updateTeamMember),
+		newRoute(http.MethodDelete, "0/organizations/{org}/members/{member}/teams/{team}/", s.deleteTeamMember),
+
+		newRoute(http.MethodGet, "0/organizations/{org}/integrations/", s.listIntegrations),
+		newRoute(http.MethodGet, "0/organizations/{org}/integrations/{integration}/", s.getIntegration),
+		newRoute(http.MethodPost, "0/organizations/{org}/integrations/{integration}/", s.updateIntegrationConfig),
+
+		newRoute(http.MethodPost, "0/organizations/{org}/notifications/actions/", s.createNotificationAction),
+		newRoute(http.MethodGet, "0/organizations/{org}/notifications/actions/{action}/", s.getNotificationAction),
+		newRoute(http.MethodPut, "0/organizations/{org}/notifications/actions/{action}/", s.updateNotificationAction),
+		newRoute(http.MethodDelete, "0/organizations/{org}/notifications/actions/{action}/", s.deleteNotificationAction),
+
+		newRoute(http.MethodGet, "0/organizations/{org}/dashboards/", s.listDashboards),
+		newRoute(http.MethodPost, "0/organizations/{org}/dashboards/", s.createDashboard),
+		newRoute(http.MethodGet, "0/organizations/{org}/dashboards/{dashboard}/", s.getDashboard),
+		newRoute(http.MethodPut, "0/organizations/{org}/dashboards/{dashboard}/", s.updateDashboard),
+		newRoute(http.MethodDelete, "0/organizations/{org}/dashboards/{dashboard}/", s.deleteDashboard),
+
+		newRoute(http.MethodPost, "0/organizations/{org}/spike-protections/", s.enableSpikeProtection),
+		newRoute(http.MethodDelete, "0/organizations/{org}/spike-protections/", s.disableSpikeProtection),
+
+		newRoute(http.MethodGet, "0/organizations/{org}/projects/", s.listProjects),
+		newRoute(http.MethodPost, "0/teams/{org}/{team}/projects/", s.createProject),
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/", s.getProject),
+		newRoute(http.MethodPut, "0/projects/{org}/{project}/", s.updateProject),
+		newRoute(http.MethodDelete, "0/projects/{org}/{project}/", s.deleteProject),
+		newRoute(http.MethodPost, "0/projects/{org}/{project}/teams/{team}/", s.addProjectTeam),
+		newRoute(http.MethodDelete, "0/projects/{org}/{project}/teams/{team}/", s.removeProjectTeam),
+
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/keys/", s.listKeys),
+		newRoute(http.MethodPost, "0/projects/{org}/{project}/keys/", s.createKey),
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/keys/{key}/", s.getKey),
+		newRoute(http.MethodPut, "0/projects/{org}/{project}/keys/{key}/", s.updateKey),
+		newRoute(http.MethodDelete, "0/projects/{org}/{project}/keys/{key}/", s.deleteKey),
+
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/rules/", s.listIssueAlerts),
+		newRoute(http.MethodPost, "0/projects/{org}/{project}/rules/", s.createIssueAlert),
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/rules/{rule}/", s.getIssueAlert),
+		newRoute(http.MethodPut, "0/projects/{org}/{project}/rules/{rule}/", s.updateIssueAlert),
+		newRoute(http.MethodDelete, "0/projects/{org}/{project}/rules/{rule}/", s.deleteIssueAlert),
+
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/alert-rules/", s.listMetricAlerts),
+		newRoute(http.MethodPost, "0/projects/{org}/{project}/alert-rules/", s.createMetricAlert),
+		newRoute(http.MethodGet, "0/organizations/{org}/alert-rules/{rule}/", s.getMetricAlert),
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/alert-rules/{rule}/", s.getMetricAlert),
+		newRoute(http.MethodPut, "0/projects/{org}/{project}/alert-rules/{rule}/", s.updateMetricAlert),
+		newRoute(http.MethodDelete, "0/projects/{org}/{project}/alert-rules/{rule}/", s.deleteMetricAlert),
+
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/symbol-sources/", s.listSymbolSources),
+		newRoute(http.MethodPost, "0/projects/{org}/{project}/symbol-sources/", s.createSymbolSource),
+		newRoute(http.MethodPut, "0/projects/{org}/{project}/symbol-sources/", s.updateSymbolSource),
+		newRoute(http.MethodDelete, "0/projects/{org}/{project}/symbol-sources/", s.deleteSymbolSource),
+
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/filters/", s.listFilters),
+		newRoute(http.MethodPut, "0/projects/{org}/{project}/filters/{filter}/", s.updateFilter),
+
+		newRoute(http.MethodGet, "0/projects/{org}/{project}/ownership/", s.getProjectOwnership),
+		newRoute(http.MethodPut, "0/projects/{org}/{project}/ownership/", s.updateProjectOwnership),
+	}
+}
+
+func (s *Server) getRoot(w http.ResponseWriter, r *http.Request, _ vars) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"version": "0",
+		"auth": map[string]interface{}{
+			"scopes": s.Scopes,
+		},
+		"user": map[string]interface{}{
+			"id":    "1",
+			"email": "owner@example.com",
+		},
+	})
+}
+
+// writeList writes a page of items, with the Link header used by Sentry for
+// cursor pagination.
+func writeList[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T) {
+	offset := 0
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		parts := strings.Split(cursor, ":")
+		if len(parts) != 3 {
+			writeError(w, http.StatusBadRequest, "Invalid cursor parameter.")
+			return
+		}
+		var err error
+		if offset, err = strconv.Atoi(parts[1]); err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid cursor parameter.")
+			return
+		}
+	}
+
+	end := min(offset+s.PageSize, len(items))
+	page := []T{}
+	if offset < len(items) {
+		page = items[offset:end]
+	}
+
+	link := func(rel string, cursor string, results bool) string {
+		u := *r.URL
+		u.Scheme = "http"
+		u.Host = r.Host
+		q := u.Query()
+		q.Set("cursor", cursor)
+		u.RawQuery = q.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"; results="%t"; cursor="%s"`, u.String(), rel, results, cursor)
+	}
+	w.Header().Set("Link", strings.Join([]string{
+		link("previous", fmt.Sprintf("0:%d:1", max(offset-s.PageSize, 0)), offset > 0),
+		link("next", fmt.Sprintf("0:%d:0", end), end < len(items)),
+	}, ", "))
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, map[string]string{"detail": detail})
+}
+
+// decode decodes the JSON request body into v, writing a 400 response if it
+// is invalid.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Malformed request: "+err.Error())
+		return false
+	}
+	return true
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// slugify returns the slug Sentry derives from a name.
+func slugify(name string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// remove removes the first item of items matching f, reporting whether one
+// did.
+func remove[T any](items *[]T, f func(T) bool) bool {
+	for i, item := range *items {
+		if f(item) {
+			*items = append((*items)[:i], (*items)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
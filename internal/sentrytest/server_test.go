@@ -0,0 +1,420 @@
+package sentrytest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/jianyuan/go-sentry/v2/sentry"
+)
+
+func newClient(t *testing.T, s *Server) *sentry.Client {
+	t.Helper()
+
+	client, err := sentry.NewOnPremiseClient(s.BaseURL(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return client
+}
+
+// statusCode returns the status code of the response to a failed request.
+func statusCode(err error) int {
+	var errResp *sentry.ErrorResponse
+	if errors.As(err, &errResp) {
+		return errResp.Response.StatusCode
+	}
+	return 0
+}
+
+func TestServerPagination(t *testing.T) {
+	t.Parallel()
+
+	s := NewUnstartedServer()
+	s.PageSize = 2
+	s.Start()
+	t.Cleanup(s.Close)
+
+	ctx := context.Background()
+	client := newClient(t, s)
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		if _, _, err := client.Teams.Create(ctx, DefaultOrganization, &sentry.CreateTeamParams{Name: sentry.String(name)}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	var slugs []string
+	pages := 0
+	params := &sentry.ListCursorParams{}
+	for {
+		teams, resp, err := client.Teams.List(ctx, DefaultOrganization, params)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		pages++
+		for _, team := range teams {
+			slugs = append(slugs, *team.Slug)
+		}
+		if resp.Cursor == "" {
+			break
+		}
+		params.Cursor = resp.Cursor
+	}
+
+	if pages != 3 {
+		t.Errorf("got %d pages, want 3", pages)
+	}
+	if got, _ := json.Marshal(slugs); string(got) != `["a","b","c","d","e"]` {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	t.Parallel()
+
+	s := NewUnstartedServer()
+	s.RateLimit = 2
+	s.ConcurrentLimit = 5
+	s.Start()
+	t.Cleanup(s.Close)
+
+	ctx := context.Background()
+	client := newClient(t, s)
+
+	_, resp, err := client.Organizations.Get(ctx, DefaultOrganization)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.Rate.Limit != 2 || resp.Rate.Remaining != 1 || resp.Rate.ConcurrentLimit != 5 || resp.Rate.ConcurrentRemaining != 4 {
+		t.Errorf("got %+v", resp.Rate)
+	}
+
+	// Both requests may not be in the same second
+	_, _, err = client.Organizations.Get(ctx, DefaultOrganization)
+	if err == nil {
+		_, _, err = client.Organizations.Get(ctx, DefaultOrganization)
+	}
+	var rateLimitErr *sentry.RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Errorf("got %v, want a rate limit error", err)
+	}
+}
+
+func TestServerToken(t *testing.T) {
+	t.Parallel()
+
+	s := NewUnstartedServer()
+	s.Token = "my-token"
+	s.Start()
+	t.Cleanup(s.Close)
+
+	_, _, err := newClient(t, s).Organizations.Get(context.Background(), DefaultOrganization)
+	if got := statusCode(err); got != http.StatusUnauthorized {
+		t.Errorf("got status %d, want 401", got)
+	}
+}
+
+func TestServerProjects(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	ctx := context.Background()
+	client := newClient(t, s)
+
+	if _, _, err := client.Teams.Create(ctx, DefaultOrganization, &sentry.CreateTeamParams{Name: sentry.String("My Team")}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	project, _, err := client.Projects.Create(ctx, DefaultOrganization, "my-team", &sentry.CreateProjectParams{Name: "My Project", Platform: "go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if project.Slug != "my-project" || project.Team.Slug == nil || *project.Team.Slug != "my-team" {
+		t.Errorf("got %+v", project)
+	}
+
+	_, _, err = client.Projects.Create(ctx, DefaultOrganization, "my-team", &sentry.CreateProjectParams{Name: "My Project"})
+	if got := statusCode(err); got != http.StatusConflict {
+		t.Errorf("got status %d, want 409", got)
+	}
+
+	// Updates only change the fields that are set
+	project, _, err = client.Projects.Update(ctx, DefaultOrganization, "my-project", &sentry.UpdateProjectParams{
+		ResolveAge: sentry.Int(24),
+		Options:    map[string]interface{}{"sentry:token": "abc"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if project.Platform != "go" || project.ResolveAge != 24 || project.Options["sentry:token"] != "abc" || project.Options[spikeProtectionDisabledOption] != false {
+		t.Errorf("got %+v", project)
+	}
+
+	if _, err := client.SpikeProtections.Disable(ctx, DefaultOrganization, &sentry.SpikeProtectionParams{Projects: []string{"$all"}}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	project, _, err = client.Projects.Get(ctx, DefaultOrganization, "my-project")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if project.Options[spikeProtectionDisabledOption] != true {
+		t.Errorf("got options %v", project.Options)
+	}
+
+	// Each project has a default key and alert rule
+	keys, _, err := client.ProjectKeys.List(ctx, DefaultOrganization, "my-project", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(keys) != 1 || keys[0].Name != "Default" || keys[0].DSN.Public == "" {
+		t.Errorf("got %+v", keys)
+	}
+	alerts, _, err := client.IssueAlerts.List(ctx, DefaultOrganization, "my-project", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(alerts) != 1 {
+		t.Errorf("got %d issue alerts, want 1", len(alerts))
+	}
+
+	key, _, err := client.ProjectKeys.Create(ctx, DefaultOrganization, "my-project", &sentry.CreateProjectKeyParams{
+		Name:      "My Key",
+		RateLimit: &sentry.ProjectKeyRateLimit{Window: 60, Count: 10},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	key, _, err = client.ProjectKeys.Update(ctx, DefaultOrganization, "my-project", key.ID, &sentry.UpdateProjectKeyParams{Name: "Renamed"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if key.Name != "Renamed" || key.RateLimit != nil {
+		t.Errorf("got %+v", key)
+	}
+	if _, err := client.ProjectKeys.Delete(ctx, DefaultOrganization, "my-project", key.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := client.Projects.Delete(ctx, DefaultOrganization, "my-project"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, _, err = client.Projects.Get(ctx, DefaultOrganization, "my-project")
+	if got := statusCode(err); got != http.StatusNotFound {
+		t.Errorf("got status %d, want 404", got)
+	}
+}
+
+func TestServerMembers(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	ctx := context.Background()
+	client := newClient(t, s)
+
+	if _, _, err := client.Teams.Create(ctx, DefaultOrganization, &sentry.CreateTeamParams{Slug: sentry.String("my-team")}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	member, _, err := client.OrganizationMembers.Create(ctx, DefaultOrganization, &sentry.CreateOrganizationMemberParams{
+		Email: "jane@example.com",
+		Role:  "member",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, _, err := client.TeamMembers.Create(ctx, DefaultOrganization, member.ID, "my-team"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	updated, _, err := client.TeamMembers.Update(ctx, DefaultOrganization, member.ID, "my-team", &sentry.UpdateTeamMemberParams{TeamRole: sentry.String("admin")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *updated.TeamRole != "admin" {
+		t.Errorf("got team role %q, want admin", *updated.TeamRole)
+	}
+
+	member, _, err = client.OrganizationMembers.Get(ctx, DefaultOrganization, member.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(member.TeamRoles) != 1 || member.TeamRoles[0].TeamSlug != "my-team" || *member.TeamRoles[0].Role != "admin" {
+		t.Errorf("got team roles %+v", member.TeamRoles)
+	}
+
+	team, _, err := client.Teams.Get(ctx, DefaultOrganization, "my-team")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *team.MemberCount != 1 {
+		t.Errorf("got %d members, want 1", *team.MemberCount)
+	}
+
+	// The owner and the new member
+	members, _, err := client.OrganizationMembers.List(ctx, DefaultOrganization, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(members) != 2 {
+		t.Errorf("got %d members, want 2", len(members))
+	}
+}
+
+func TestServerIntegrations(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	ctx := context.Background()
+	client := newClient(t, s)
+
+	id := s.AddIntegration(DefaultOrganization, "pagerduty", "my-pagerduty")
+	s.AddIntegration(DefaultOrganization, "slack", "my-slack")
+
+	integrations, _, err := client.OrganizationIntegrations.List(ctx, DefaultOrganization, &sentry.ListOrganizationIntegrationsParams{ProviderKey: "pagerduty"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(integrations) != 1 || integrations[0].ID != id {
+		t.Errorf("got %+v", integrations)
+	}
+
+	config := json.RawMessage(`{"service_table":[{"service":"my-service","integration_key":"abc","id":0}]}`)
+	if _, err := client.OrganizationIntegrations.UpdateConfig(ctx, DefaultOrganization, id, &config); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	integration, _, err := client.OrganizationIntegrations.Get(ctx, DefaultOrganization, id)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var configData struct {
+		ServiceTable []struct {
+			ID json.Number `json:"id"`
+		} `json:"service_table"`
+	}
+	if err := json.Unmarshal(integration.ConfigData, &configData); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(configData.ServiceTable) != 1 || configData.ServiceTable[0].ID == "0" {
+		t.Errorf("got %s, want the row to have an ID", integration.ConfigData)
+	}
+}
+
+func TestServerAlerts(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	ctx := context.Background()
+	client := newClient(t, s)
+
+	if _, _, err := client.Teams.Create(ctx, DefaultOrganization, &sentry.CreateTeamParams{Slug: sentry.String("my-team")}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, _, err := client.Projects.Create(ctx, DefaultOrganization, "my-team", &sentry.CreateProjectParams{Name: "my-project", DefaultRules: sentry.Bool(false)}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	issueAlert, _, err := client.IssueAlerts.Create(ctx, DefaultOrganization, "my-project", &sentry.IssueAlert{
+		Name:        sentry.String("my-alert"),
+		ActionMatch: sentry.String("any"),
+		Actions:     []map[string]interface{}{{"id": "sentry.mail.actions.NotifyEmailAction"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	issueAlert, _, err = client.IssueAlerts.Get(ctx, DefaultOrganization, "my-project", *issueAlert.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *issueAlert.FilterMatch != "all" || issueAlert.Projects[0] != "my-project" {
+		t.Errorf("got %+v", issueAlert)
+	}
+
+	metricAlert, _, err := client.MetricAlerts.Create(ctx, DefaultOrganization, "my-project", &sentry.MetricAlert{
+		Name:       sentry.String("my-alert"),
+		Aggregate:  sentry.String("count()"),
+		TimeWindow: sentry.Float64(60),
+		Triggers: []*sentry.MetricAlertTrigger{{
+			Label:          sentry.String("critical"),
+			AlertThreshold: sentry.Float64(100),
+			Actions: []*sentry.MetricAlertTriggerAction{{
+				Type:       sentry.String("email"),
+				TargetType: sentry.String("team"),
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	metricAlert, _, err = client.MetricAlerts.Get(ctx, DefaultOrganization, "my-project", *metricAlert.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	trigger := metricAlert.Triggers[0]
+	if trigger.ID == nil || *trigger.AlertRuleID != *metricAlert.ID || trigger.Actions[0].ID == nil {
+		t.Errorf("got %+v, want IDs assigned", trigger)
+	}
+
+	if _, err := client.MetricAlerts.Delete(ctx, DefaultOrganization, "my-project", *metricAlert.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, _, err = client.MetricAlerts.Get(ctx, DefaultOrganization, "my-project", *metricAlert.ID)
+	if got := statusCode(err); got != http.StatusNotFound {
+		t.Errorf("got status %d, want 404", got)
+	}
+}
+
+func TestServerSymbolSources(t *testing.T) {
+	t.Parallel()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	ctx := context.Background()
+	client := newClient(t, s)
+
+	if _, _, err := client.Teams.Create(ctx, DefaultOrganization, &sentry.CreateTeamParams{Slug: sentry.String("my-team")}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, _, err := client.Projects.Create(ctx, DefaultOrganization, "my-team", &sentry.CreateProjectParams{Name: "my-project"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	source, _, err := client.ProjectSymbolSources.Create(ctx, DefaultOrganization, "my-project", &sentry.CreateProjectSymbolSourceParams{
+		Type:     sentry.String("http"),
+		Name:     sentry.String("my-source"),
+		Url:      sentry.String("https://example.com"),
+		Password: sentry.String("hunter2"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if source.Password == nil || !*source.Password.HiddenSecret {
+		t.Errorf("got password %+v, want it hidden", source.Password)
+	}
+
+	sources, _, err := client.ProjectSymbolSources.List(ctx, DefaultOrganization, "my-project", &sentry.ProjectSymbolSourceQueryParams{ID: source.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sources) != 1 || *sources[0].Name != "my-source" {
+		t.Errorf("got %+v", sources)
+	}
+
+	if _, err := client.ProjectSymbolSources.Delete(ctx, DefaultOrganization, "my-project", *source.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, _, err = client.ProjectSymbolSources.List(ctx, DefaultOrganization, "my-project", &sentry.ProjectSymbolSourceQueryParams{ID: source.ID})
+	if got := statusCode(err); got != http.StatusNotFound {
+		t.Errorf("got status %d, want 404", got)
+	}
+}
@@ -40,8 +40,9 @@ func main() {
 		context.Background(),
 		sentry.NewProvider(version)().GRPCProvider,
 	))
+	sentryProvider := provider.New(version)()
 	providers := []func() tfprotov6.ProviderServer{
-		providerserver.NewProtocol6(provider.New(version)()),
+		providerserver.NewProtocol6(sentryProvider),
 		func() tfprotov6.ProviderServer {
 			return upgradedSdkProvider
 		},
@@ -61,7 +62,9 @@ func main() {
 
 	err = tf6server.Serve(
 		"registry.terraform.io/jianyuan/sentry",
-		muxServer.ProviderServer,
+		func() tfprotov6.ProviderServer {
+			return provider.NewOperationServer(muxServer.ProviderServer(), sentryProvider)
+		},
 		serveOpts...,
 	)
 
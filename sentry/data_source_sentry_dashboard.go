@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 func dataSourceSentryDashboard() *schema.Resource {
@@ -16,9 +17,10 @@ func dataSourceSentryDashboard() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the dashboard belongs to.",
+				Description: "The slug of the organization the dashboard belongs to. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"internal_id": {
 				Description: "The internal ID for this dashboard.",
@@ -148,9 +150,12 @@ func dataSourceSentryDashboard() *schema.Resource {
 }
 
 func dataSourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, "organization", meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	dashboardID := d.Get("internal_id").(string)
 
 	tflog.Debug(ctx, "Reading dashboard", map[string]interface{}{
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 func dataSourceSentryMetricAlert() *schema.Resource {
@@ -16,9 +17,10 @@ func dataSourceSentryMetricAlert() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the metric alert belongs to.",
+				Description: "The slug of the organization the metric alert belongs to. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"project": {
 				Description: "The slug of the project the metric alert belongs to.",
@@ -140,9 +142,12 @@ func dataSourceSentryMetricAlert() *schema.Resource {
 }
 
 func dataSourceSentryMetricAlertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, "organization", meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	project := d.Get("project").(string)
 	alertID := d.Get("internal_id").(string)
 
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 func dataSourceSentryOrganization() *schema.Resource {
@@ -18,9 +19,10 @@ func dataSourceSentryOrganization() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"slug": {
-				Description: "The unique URL slug for this organization.",
+				Description: "The unique URL slug for this organization. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"internal_id": {
 				Description: "The internal ID for this organization.",
@@ -37,9 +39,12 @@ func dataSourceSentryOrganization() *schema.Resource {
 }
 
 func dataSourceSentryOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
-	org := d.Get("slug").(string)
+	org, err := getOrganization(d, "slug", meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	tflog.Debug(ctx, "Reading organization", map[string]interface{}{"org": org})
 	organization, _, err := client.Organizations.Get(ctx, org)
@@ -49,6 +54,7 @@ func dataSourceSentryOrganizationRead(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(sentry.StringValue(organization.Slug))
 	retErr := multierror.Append(
+		d.Set("slug", organization.Slug),
 		d.Set("name", organization.Name),
 		d.Set("internal_id", organization.ID),
 	)
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 func dataSourceSentryTeam() *schema.Resource {
@@ -18,9 +19,10 @@ func dataSourceSentryTeam() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the team should be created for.",
+				Description: "The slug of the organization the team should be created for. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 			},
 			"slug": {
 				Description: "The unique URL slug for this team.",
@@ -54,9 +56,12 @@ func dataSourceSentryTeam() *schema.Resource {
 }
 
 func dataSourceSentryTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
-	org := d.Get("organization").(string)
+	org, err := getOrganization(d, "organization", meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	teamSlug := d.Get("slug").(string)
 
 	tflog.Debug(ctx, "Reading team", map[string]interface{}{
@@ -0,0 +1,88 @@
+package sentry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+)
+
+// diagFromClientErr returns the diagnostics for an error returned by a request
+// made from the resource data. Each validation error of a field of the
+// request is attached to the attribute of resourceSchema the field was set
+// from, see sentryclient.AttributePath for fieldPaths.
+func diagFromClientErr(err error, resourceSchema map[string]*schema.Schema, fieldPaths map[string]string) diag.Diagnostics {
+	details, ok := sentryclient.ParseErrorResponse(err)
+	if !ok {
+		return diag.FromErr(err)
+	}
+
+	var requestID string
+	if details.RequestID != "" {
+		requestID = fmt.Sprintf("Sentry request ID: %s", details.RequestID)
+	}
+
+	if len(details.FieldErrors) == 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   requestID,
+		}}
+	}
+
+	var diags diag.Diagnostics
+	for _, m := range details.Messages {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  m,
+			Detail:   requestID,
+		})
+	}
+	for _, fieldErr := range details.FieldErrors {
+		attributePath, exact := schemaAttributePath(resourceSchema, sentryclient.AttributePath(fieldErr.Path, fieldPaths))
+
+		summary := fieldErr.Message
+		if !exact {
+			summary = fmt.Sprintf("%s: %s", strings.Join(fieldErr.Path, "."), summary)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       summary,
+			Detail:        requestID,
+			AttributePath: attributePath,
+		})
+	}
+	return diags
+}
+
+// schemaAttributePath returns the longest prefix of attributePath that is in
+// resourceSchema, and whether it is the whole path.
+func schemaAttributePath(resourceSchema map[string]*schema.Schema, attributePath []string) (cty.Path, bool) {
+	var p cty.Path
+	schemas := resourceSchema
+	var current *schema.Schema
+	for _, segment := range attributePath {
+		if index, err := strconv.Atoi(segment); err == nil && current != nil && current.Type == schema.TypeList {
+			p = p.IndexInt(index)
+			schemas = nil
+			if elem, ok := current.Elem.(*schema.Resource); ok {
+				schemas = elem.Schema
+			}
+			current = nil
+			continue
+		}
+
+		next, ok := schemas[segment]
+		if !ok {
+			return p, false
+		}
+		p = p.GetAttr(segment)
+		schemas = nil
+		current = next
+	}
+	return p, true
+}
@@ -1,14 +1,19 @@
 package sentry
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
 )
 
 func buildTwoPartID(a, b string) string {
@@ -40,6 +45,47 @@ func splitSentryAlertID(id string) (org string, project string, alertID string,
 	return
 }
 
+// errMissingOrganization is returned when neither the resource nor the provider
+// sets the organization.
+var errMissingOrganization = errors.New("organization must be set either on the resource or on the provider (or via the SENTRY_ORGANIZATION environment variable)")
+
+// customizeDiffDefaultOrganization plans the provider-level default
+// organization for resources that do not set `organization`.
+func customizeDiffDefaultOrganization(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.GetAttr("organization").IsNull() {
+		return nil
+	}
+
+	// The provider may not be configured yet, e.g. during validation.
+	data, ok := meta.(*providerdata.ProviderData)
+	if !ok {
+		return nil
+	}
+
+	if data.DefaultOrganization == "" {
+		return errMissingOrganization
+	}
+
+	if d.Get("organization").(string) == data.DefaultOrganization {
+		return nil
+	}
+	return d.SetNew("organization", data.DefaultOrganization)
+}
+
+// getOrganization returns the organization set on the data source under key,
+// falling back to the provider-level default.
+func getOrganization(d *schema.ResourceData, key string, meta interface{}) (string, error) {
+	if org := d.Get(key).(string); org != "" {
+		return org, nil
+	}
+
+	if org := meta.(*providerdata.ProviderData).DefaultOrganization; org != "" {
+		return org, nil
+	}
+	return "", errMissingOrganization
+}
+
 func SuppressEquivalentJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
 	var o interface{}
 	if err := json.Unmarshal([]byte(old), &o); err != nil {
@@ -84,6 +130,13 @@ func followShape(shape, value interface{}) interface{} {
 	}
 }
 
+// isSetInConfig reports whether the attribute is set in the configuration,
+// unlike GetOk which does not tell zero values apart from unset ones.
+func isSetInConfig(d *schema.ResourceData, key string) bool {
+	config := d.GetRawConfig()
+	return !config.IsNull() && !config.GetAttr(key).IsNull()
+}
+
 func flattenStringSet(strings []string) *schema.Set {
 	flattenedStrings := schema.NewSet(schema.HashString, []interface{}{})
 	for _, v := range strings {
@@ -102,20 +155,168 @@ func expandStringList(configured []interface{}) []string {
 	return vs
 }
 
-// checkClientGet returns a `found` bool and an `error` to indicate if a Get request was successful.
-// The following return values are meaningful:
-// `true`, `nil` => a resource was successfully found
-// `false`, `nil` => a resource was successfully not found
-// `false`, `err` => encountered an unexpected error
-func checkClientGet(resp *sentry.Response, err error, d *schema.ResourceData) (bool, error) {
-	if err != nil {
-		if resp != nil && resp.Response.StatusCode == http.StatusNotFound {
-			d.SetId("")
-			return false, nil
+// checkClientGet returns whether the object of a resource was found by a Get
+// request, and the diagnostics to return from Read if it was not. An object
+// that no longer exists is removed from the state with a warning, so that
+// Terraform plans to create it again.
+func checkClientGet(err error, d *schema.ResourceData) (bool, diag.Diagnostics) {
+	if err == nil {
+		return true, nil
+	}
+
+	if sentryclient.IsNotFound(err) {
+		return false, removeNotFound(d)
+	}
+
+	return false, diag.FromErr(err)
+}
+
+// removeNotFound removes the object of a resource that no longer exists from
+// the state, and returns the warning to return from Read.
+func removeNotFound(d *schema.ResourceData) diag.Diagnostics {
+	id := d.Id()
+	d.SetId("")
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Resource not found",
+		Detail:   fmt.Sprintf("%q no longer exists in Sentry and has been removed from the state.", id),
+	}}
+}
+
+// checkClientDelete returns the diagnostics of a Delete request. Deleting an
+// object that no longer exists succeeds.
+func checkClientDelete(err error) diag.Diagnostics {
+	if sentryclient.IsNotFound(err) {
+		return nil
+	}
+
+	return diag.FromErr(err)
+}
+
+// resourceTimeouts returns the default timeouts of the operations of a
+// resource, which enable its `timeouts` block.
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(providerdata.DefaultCreateTimeout),
+		Read:   schema.DefaultTimeout(providerdata.DefaultReadTimeout),
+		Update: schema.DefaultTimeout(providerdata.DefaultUpdateTimeout),
+		Delete: schema.DefaultTimeout(providerdata.DefaultDeleteTimeout),
+	}
+}
+
+// deletionProtectionSchema returns the `deletion_protection` attribute of the
+// resources that Terraform can be prevented from destroying.
+func deletionProtectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Whether Terraform is prevented from destroying or replacing the resource. Set it to `false` and " +
+			"apply before destroying the resource. Defaults to the provider-level `deletion_protection`.",
+		Type:     schema.TypeBool,
+		Optional: true,
+		Computed: true,
+	}
+}
+
+// customizeDiffDeletionProtection plans the provider-level default of
+// `deletion_protection` for resources that do not set it, and fails to plan
+// the replacement of protected resources. forceNewKeys are the attributes
+// that force the replacement of the resource.
+func customizeDiffDeletionProtection(forceNewKeys ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		// The provider may not be configured yet, e.g. during validation.
+		data, ok := meta.(*providerdata.ProviderData)
+		if !ok {
+			return nil
+		}
+
+		if d.Id() != "" && d.HasChanges(forceNewKeys...) {
+			protected := data.DeletionProtection
+			if state := d.GetRawState(); !state.IsNull() && !state.GetAttr("deletion_protection").IsNull() {
+				protected = state.GetAttr("deletion_protection").True()
+			}
+			if protected {
+				return fmt.Errorf("deletion protection is enabled: %q cannot be destroyed or replaced while `deletion_protection` is true. "+
+					"Set `deletion_protection` to false and apply before replacing it", d.Id())
+			}
 		}
 
-		return false, err
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.GetAttr("deletion_protection").IsNull() {
+			return nil
+		}
+		if d.Id() != "" && d.Get("deletion_protection").(bool) == data.DeletionProtection {
+			return nil
+		}
+		return d.SetNew("deletion_protection", data.DeletionProtection)
 	}
+}
 
-	return true, nil
+// checkDeletionProtection returns an error diagnostic if the resource is
+// protected from being destroyed, which also prevents its replacement. The
+// provider-level default applies to resources imported or created before the
+// attribute existed.
+func checkDeletionProtection(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	protected := meta.(*providerdata.ProviderData).DeletionProtection
+	if state := d.GetRawState(); !state.IsNull() && !state.GetAttr("deletion_protection").IsNull() {
+		protected = d.Get("deletion_protection").(bool)
+	}
+	if !protected {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "Deletion protection is enabled",
+		Detail: fmt.Sprintf("%q cannot be destroyed or replaced while `deletion_protection` is true. "+
+			"Set `deletion_protection` to false and apply before destroying it.", d.Id()),
+		AttributePath: cty.GetAttrPath("deletion_protection"),
+	}}
+}
+
+// setDefaultDeletionProtection sets the provider-level default of
+// `deletion_protection` in the state of resources that do not have it, such as
+// imported resources.
+func setDefaultDeletionProtection(d *schema.ResourceData, meta interface{}) error {
+	if state := d.GetRawState(); !state.IsNull() && !state.GetAttr("deletion_protection").IsNull() {
+		return nil
+	}
+	return d.Set("deletion_protection", meta.(*providerdata.ProviderData).DeletionProtection)
+}
+
+// deletionPollInterval is how often waitForDeletion checks whether an object
+// is gone.
+var deletionPollInterval = 2 * time.Second
+
+// waitForDeletion waits for an object that Sentry deletes asynchronously to be
+// gone, polling exists until it returns false or ctx, which carries the delete
+// timeout, ends. The object keeps its slug reserved until it is gone, so the
+// object of a replaced resource must be gone before it is created again.
+//
+// If the object still exists at the deadline, the deletion is left to Sentry
+// and a warning is returned.
+func waitForDeletion(ctx context.Context, d *schema.ResourceData, exists func(ctx context.Context) (bool, error)) diag.Diagnostics {
+	for {
+		ok, err := exists(ctx)
+		if ctx.Err() != nil {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Deletion not complete",
+				Detail: fmt.Sprintf("Sentry accepted the deletion of %q, but it still exists after the delete timeout. "+
+					"Its slug stays reserved until Sentry completes the deletion.", d.Id()),
+			}}
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !ok {
+			return nil
+		}
+
+		timer := time.NewTimer(deletionPollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
 }
@@ -1,8 +1,13 @@
 package sentry
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestFollowShape(t *testing.T) {
@@ -102,3 +107,34 @@ func TestFollowShape(t *testing.T) {
 		})
 	}
 }
+
+func TestWaitForDeletion(t *testing.T) {
+	defer func(interval time.Duration) { deletionPollInterval = interval }(deletionPollInterval)
+	deletionPollInterval = 10 * time.Millisecond
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("my-project")
+
+	// Gone after a few polls
+	var polls int
+	diags := waitForDeletion(context.Background(), d, func(ctx context.Context) (bool, error) {
+		polls++
+		return polls < 3, nil
+	})
+	if len(diags) != 0 {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+	if polls != 3 {
+		t.Errorf("got %d polls, want 3", polls)
+	}
+
+	// Still pending deletion at the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	diags = waitForDeletion(ctx, d, func(ctx context.Context) (bool, error) {
+		return true, nil
+	})
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("got %v, want a warning", diags)
+	}
+}
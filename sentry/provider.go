@@ -2,10 +2,18 @@ package sentry
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+	"golang.org/x/oauth2"
 )
 
 func init() {
@@ -21,19 +29,176 @@ func NewProvider(version string) func() *schema.Provider {
 			Schema: map[string]*schema.Schema{
 				"token": {
 					Description: "The authentication token used to connect to Sentry. The value can be sourced from " +
-						"the `SENTRY_AUTH_TOKEN` environment variable.",
-					Type:        schema.TypeString,
-					Optional:    true,
-					DefaultFunc: schema.MultiEnvDefaultFunc([]string{"SENTRY_AUTH_TOKEN", "SENTRY_TOKEN"}, nil),
-					Sensitive:   true,
+						"the `SENTRY_AUTH_TOKEN` environment variable. Conflicts with `token_file` and `token_command`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{"token_file", "token_command"},
+				},
+				"token_file": {
+					Description: "The path to a file containing the authentication token. The file is read each time " +
+						"the provider is configured. Conflicts with `token` and `token_command`.",
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"token", "token_command"},
+				},
+				"token_command": {
+					Description: "A command that prints the authentication token to its standard output, as a list of " +
+						"the executable followed by its arguments, e.g. " +
+						"`[\"vault\", \"read\", \"-field=token\", \"secret/sentry\"]`. The command is killed if it runs for more than 30 seconds. " +
+						"Conflicts with `token` and `token_file`.",
+					Type:          schema.TypeList,
+					Optional:      true,
+					ConflictsWith: []string{"token", "token_file"},
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"token_command_ttl": {
+					Description: "How long the token printed by `token_command` is cached before the command is run " +
+						"again, as a duration string such as `5m`. The default value is `5m`.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"token_scope_check": {
+					Description: "Whether to check that the token has the scopes each resource needs before changing " +
+						"it: `off`, `warn` or `error`. When enabled, the provider reads the type and scopes of the token when " +
+						"it is configured, plans that create or update a resource show a warning or an error naming the " +
+						"missing scopes, and 403 errors name them too. The default value is `off`. The check runs in the muxed " +
+						"provider server, so it covers the resources of both the plugin framework and SDK implementations.",
+					Type:     schema.TypeString,
+					Optional: true,
+					ValidateFunc: validation.StringInSlice([]string{
+						providerdata.TokenScopeCheckOff,
+						providerdata.TokenScopeCheckWarn,
+						providerdata.TokenScopeCheckError,
+					}, false),
 				},
 				"base_url": {
 					Description: "The target Sentry Base API URL in the format `https://[hostname]/api/`. " +
-						"The default value is `https://sentry.io/api/`. The value must be provided when working with " +
-						"Sentry On-Premise. The value can be sourced from the `SENTRY_BASE_URL` environment variable.",
-					Type:        schema.TypeString,
+						"The default value is `https://sentry.io/api/`, in which case the requests for each organization " +
+						"are sent to the API host of the region the organization is hosted in. The value must be provided " +
+						"when working with Sentry On-Premise. The value can be sourced from the `SENTRY_BASE_URL` environment variable.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"organization": {
+					Description: "The default organization slug for resources and data sources that do not set " +
+						"`organization`. The value can be sourced from the `SENTRY_ORGANIZATION` environment variable.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"read_only": {
+					Description: "Whether to reject every request that would change Sentry, without sending it. Reads, imports " +
+						"and data sources keep working, whatever the scopes of the token. Read-only mode is also enabled when " +
+						"the `SENTRY_READ_ONLY` environment variable is set to `true`. The default value is `false`.",
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"audit_log_path": {
+					Description: "The path of a file to append a JSON line to for every request that changes Sentry. Each " +
+						"line records the time, the Terraform resource type and operation, the HTTP method, path and status " +
+						"code, the Sentry request ID, and the request body with secrets redacted. The file is created if it " +
+						"does not exist.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"deletion_protection": {
+					Description: "The default value of `deletion_protection` for the resources that support it: `sentry_dashboard`, " +
+						"`sentry_key`, `sentry_organization`, `sentry_project` and `sentry_team`. While it is true, " +
+						"destroying or replacing these resources fails. The default value is `false`.",
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"adopt_on_ambiguous_create": {
+					Description: "Whether to look up the object of a resource when creating it fails in a way that leaves it unknown " +
+						"whether Sentry created it, such as a timeout, a connection error or a server error. An object " +
+						"matching the resource, such as an alert with the same name in the same project, that was created " +
+						"since is adopted into the state instead of creating a duplicate. Applies to `sentry_dashboard`, " +
+						"`sentry_issue_alert`, `sentry_key`, `sentry_metric_alert` and `sentry_team`. The default value is " +
+						"`true`.",
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+				},
+				"max_retries": {
+					Description: "The maximum number of retries for a failed request. The default value is `4`.",
+					Type:        schema.TypeInt,
 					Optional:    true,
-					DefaultFunc: schema.EnvDefaultFunc("SENTRY_BASE_URL", "https://sentry.io/api/"),
+					Default:     sentryclient.DefaultMaxRetries,
+				},
+				"min_backoff": {
+					Description: "The minimum time to wait before retrying a failed request, as a duration string " +
+						"such as `1s`. The default value is `1s`.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"max_backoff": {
+					Description: "The maximum time to wait before retrying a failed request, as a duration string " +
+						"such as `30s`. The default value is `30s`.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"request_timeout": {
+					Description: "The timeout for each request attempt, as a duration string such as `1m`. " +
+						"By default, requests do not time out.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"retry_status_codes": {
+					Description: "The HTTP status codes of responses that are retried. By default, `429` and all " +
+						"`5xx` status codes except `501` are retried. Rate limited (`429`) responses are always retried.",
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeInt,
+					},
+				},
+				"max_rate_limit_wait": {
+					Description: "The maximum time to wait for a rate limit to reset before retrying, as a duration " +
+						"string such as `1m`. The default value is `1m`.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"max_concurrent_requests": {
+					Description: "The maximum number of concurrent requests to Sentry. The provider adapts to the " +
+						"concurrency and rate limits reported by Sentry on each response and never exceeds this value. " +
+						"By default, the concurrency limit reported by Sentry is used, or `10` if Sentry does not report one.",
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+				"ca_cert": {
+					Description: "A PEM-encoded CA certificate bundle, or the path to one, used to verify the Sentry server " +
+						"certificate in addition to the system certificate pool. Useful when self-hosting Sentry behind an " +
+						"internal CA.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"client_cert": {
+					Description: "A PEM-encoded client certificate, or the path to one, used for mutual TLS authentication. Must be " +
+						"set together with `client_key`.",
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"client_key": {
+					Description: "A PEM-encoded client private key, or the path to one, used for mutual TLS authentication. Must be " +
+						"set together with `client_cert`.",
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+				},
+				"insecure_skip_verify": {
+					Description: "Whether to skip verification of the Sentry server certificate. This is insecure and should only be " +
+						"used for testing. The default value is `false`.",
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"proxy_url": {
+					Description: "The URL of the HTTP(S) proxy used to connect to Sentry, e.g. `http://proxy.example.com:8080`. By " +
+						"default, the proxy is sourced from the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment " +
+						"variables.",
+					Type:     schema.TypeString,
+					Optional: true,
 				},
 			},
 
@@ -65,17 +230,125 @@ func NewProvider(version string) func() *schema.Provider {
 
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		var token string
+		var tokenSource oauth2.TokenSource
+		if v := d.Get("token").(string); v != "" {
+			token = v
+		} else if v := d.Get("token_file").(string); v != "" {
+			v, err := sentryclient.ReadTokenFile(v)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			token = v
+		} else if v := d.Get("token_command").([]interface{}); len(v) > 0 {
+			ttl, err := parseDuration(d, "token_command_ttl")
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+
+			tokenSource = sentryclient.NewCommandTokenSource(expandStringList(v), ttl)
+			if _, err := tokenSource.Token(); err != nil {
+				return nil, diag.FromErr(err)
+			}
+		} else if v := os.Getenv("SENTRY_AUTH_TOKEN"); v != "" {
+			token = v
+		} else if v := os.Getenv("SENTRY_TOKEN"); v != "" {
+			token = v
+		}
+
+		baseUrl := d.Get("base_url").(string)
+		if baseUrl == "" {
+			baseUrl = os.Getenv("SENTRY_BASE_URL")
+		}
+
+		organization := d.Get("organization").(string)
+		if organization == "" {
+			organization = os.Getenv("SENTRY_ORGANIZATION")
+		}
+
+		readOnly := d.Get("read_only").(bool)
+		if v := os.Getenv("SENTRY_READ_ONLY"); v != "" {
+			envReadOnly, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, diag.Errorf("invalid SENTRY_READ_ONLY environment variable: %s", err)
+			}
+			readOnly = readOnly || envReadOnly
+		}
+
+		// Fall back to the sentry-cli configuration files
+		if err := sentryclient.ApplyCliConfig(&token, tokenSource, &baseUrl, &organization); err != nil {
+			return nil, diag.Errorf("failed to load .sentryclirc: %s", err)
+		}
+
 		config := sentryclient.Config{
-			UserAgent: p.UserAgent("terraform-provider-sentry", version),
-			Token:     d.Get("token").(string),
-			BaseURL:   d.Get("base_url").(string),
+			UserAgent:   p.UserAgent("terraform-provider-sentry", version),
+			Token:       token,
+			TokenSource: tokenSource,
+			BaseURL:     baseUrl,
+			Cache:       sentryclient.SharedCache(baseUrl, token, tokenSource),
+			ReadOnly:    readOnly,
+
+			MaxRetries: sentry.Int(d.Get("max_retries").(int)),
+
+			CACert:             d.Get("ca_cert").(string),
+			ClientCert:         d.Get("client_cert").(string),
+			ClientKey:          d.Get("client_key").(string),
+			InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+			ProxyURL:           d.Get("proxy_url").(string),
+
+			MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		}
+
+		var err error
+		if config.MinBackoff, err = parseDuration(d, "min_backoff"); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if config.MaxBackoff, err = parseDuration(d, "max_backoff"); err != nil {
+			return nil, diag.FromErr(err)
 		}
+		if config.RequestTimeout, err = parseDuration(d, "request_timeout"); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if config.MaxRateLimitWait, err = parseDuration(d, "max_rate_limit_wait"); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		for _, code := range d.Get("retry_status_codes").(*schema.Set).List() {
+			config.RetryStatusCodes = append(config.RetryStatusCodes, code.(int))
+		}
+		if v := d.Get("audit_log_path").(string); v != "" {
+			if config.AuditLog, err = sentryclient.OpenAuditLog(v); err != nil {
+				return nil, diag.Errorf("unable to open audit log: %s", err)
+			}
+		}
+
 		client, err := config.Client(ctx)
 
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
-		return client, nil
+		return &providerdata.ProviderData{
+			Client:              client,
+			DefaultOrganization: organization,
+			Cache:               config.Cache,
+
+			DeletionProtection:     d.Get("deletion_protection").(bool),
+			AdoptOnAmbiguousCreate: d.Get("adopt_on_ambiguous_create").(bool),
+		}, nil
+	}
+}
+
+// parseDuration parses an optional duration attribute, returning zero if it is
+// not set.
+func parseDuration(d *schema.ResourceData, key string) (time.Duration, error) {
+	v := d.Get(key).(string)
+	if v == "" {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", key, err)
 	}
+	return duration, nil
 }
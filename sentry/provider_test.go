@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jianyuan/terraform-provider-sentry/internal/acctest"
 	"github.com/jianyuan/terraform-provider-sentry/internal/pkg/must"
 	"github.com/jianyuan/terraform-provider-sentry/internal/provider"
@@ -21,8 +22,9 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 			context.Background(),
 			NewProvider(acctest.ProviderVersion)().GRPCProvider,
 		))
+		sentryProvider := provider.New(acctest.ProviderVersion)()
 		providers := []func() tfprotov6.ProviderServer{
-			providerserver.NewProtocol6(provider.New(acctest.ProviderVersion)()),
+			providerserver.NewProtocol6(sentryProvider),
 			func() tfprotov6.ProviderServer {
 				return upgradedSdkProvider
 			},
@@ -34,7 +36,7 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 			return nil, err
 		}
 
-		return muxServer.ProviderServer(), nil
+		return provider.NewOperationServer(muxServer.ProviderServer(), sentryProvider), nil
 	},
 }
 
@@ -43,3 +45,44 @@ func TestProvider(t *testing.T) {
 		t.Fatalf("err: %s", err)
 	}
 }
+
+func TestProviderValidate(t *testing.T) {
+	testCases := map[string]struct {
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		"token": {
+			config: map[string]interface{}{"token": "abc"},
+		},
+		"token and token_file": {
+			config:  map[string]interface{}{"token": "abc", "token_file": "token.txt"},
+			wantErr: true,
+		},
+		"token and token_command": {
+			config:  map[string]interface{}{"token": "abc", "token_command": []interface{}{"echo", "abc"}},
+			wantErr: true,
+		},
+		"token_file and token_command": {
+			config:  map[string]interface{}{"token_file": "token.txt", "token_command": []interface{}{"echo", "abc"}},
+			wantErr: true,
+		},
+		"token_scope_check": {
+			config: map[string]interface{}{"token_scope_check": "warn"},
+		},
+		"invalid token_scope_check": {
+			config:  map[string]interface{}{"token_scope_check": "errror"},
+			wantErr: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			diags := NewProvider("dev")().Validate(terraform.NewResourceConfigRaw(testCase.config))
+			if got := diags.HasError(); got != testCase.wantErr {
+				t.Errorf("got error %t, want %t: %v", got, testCase.wantErr, diags)
+			}
+		})
+	}
+}
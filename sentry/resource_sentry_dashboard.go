@@ -2,16 +2,27 @@ package sentry
 
 import (
 	"context"
-	"net/http"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
+// dashboardFieldPaths maps the fields of dashboard requests to the attributes
+// they are set from.
+var dashboardFieldPaths = map[string]string{
+	"widgets":                     "widget",
+	"widgets.*.queries":           "widget.*.query",
+	"widgets.*.queries.*.orderby": "widget.*.query.*.order_by",
+	"widgets.*.layout":            "widget.*.layout.0",
+}
+
 func resourceSentryDashboard() *schema.Resource {
 	return &schema.Resource{
 		Description: "Sentry Dashboard resource.",
@@ -24,12 +35,20 @@ func resourceSentryDashboard() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
+
+		CustomizeDiff: customdiff.All(
+			customizeDiffDefaultOrganization,
+			customizeDiffDeletionProtection("organization"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the dashboard belongs to.",
+				Description: "The slug of the organization the dashboard belongs to. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"title": {
 				Description: "Dashboard title.",
@@ -186,6 +205,7 @@ func resourceSentryDashboard() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
@@ -253,8 +273,6 @@ func resourceSentryDashboardObject(d *schema.ResourceData) *sentry.Dashboard {
 }
 
 func resourceSentryDashboardCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
-
 	org := d.Get("organization").(string)
 	dashboardReq := resourceSentryDashboardObject(d)
 
@@ -262,9 +280,37 @@ func resourceSentryDashboardCreate(ctx context.Context, d *schema.ResourceData,
 		"org":   org,
 		"title": dashboardReq.Title,
 	})
-	dashboard, _, err := client.Dashboards.Create(ctx, org, dashboardReq)
+	dashboard, err := providerdata.CreateOnce(
+		ctx,
+		meta.(*providerdata.ProviderData),
+		"dashboard",
+		func(client *sentry.Client) (*sentry.Dashboard, error) {
+			dashboard, _, err := client.Dashboards.Create(ctx, org, dashboardReq)
+			return dashboard, err
+		},
+		func(client *sentry.Client, since time.Time) (*sentry.Dashboard, error) {
+			params := &sentry.ListCursorParams{}
+			for {
+				dashboards, resp, err := client.Dashboards.List(ctx, org, params)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, dashboard := range dashboards {
+					if sentry.StringValue(dashboard.Title) == sentry.StringValue(dashboardReq.Title) && dashboard.DateCreated != nil && !dashboard.DateCreated.Before(since) {
+						return dashboard, nil
+					}
+				}
+
+				if resp.Cursor == "" {
+					return nil, nil
+				}
+				params.Cursor = resp.Cursor
+			}
+		},
+	)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryDashboard().Schema, dashboardFieldPaths)
 	}
 
 	d.SetId(buildTwoPartID(org, sentry.StringValue(dashboard.ID)))
@@ -272,7 +318,7 @@ func resourceSentryDashboardCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, dashboardID, err := splitSentryDashboardID(d.Id())
 	if err != nil {
@@ -284,18 +330,8 @@ func resourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData, me
 		"dashboardID": dashboardID,
 	})
 	dashboard, _, err := client.Dashboards.Get(ctx, org, dashboardID)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing dashboard from state because it no longer exists in Sentry", map[string]interface{}{
-					"org":         org,
-					"dashboardID": dashboardID,
-				})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(err, d); !found {
+		return diags
 	}
 
 	d.SetId(buildTwoPartID(org, sentry.StringValue(dashboard.ID)))
@@ -304,12 +340,13 @@ func resourceSentryDashboardRead(ctx context.Context, d *schema.ResourceData, me
 		d.Set("title", dashboard.Title),
 		d.Set("widget", flattenDashboardWidgets(dashboard.Widgets)),
 		d.Set("internal_id", dashboard.ID),
+		setDefaultDeletionProtection(d, meta),
 	)
 	return diag.FromErr(retErr.ErrorOrNil())
 }
 
 func resourceSentryDashboardUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, dashboardID, err := splitSentryDashboardID(d.Id())
 	if err != nil {
@@ -323,13 +360,17 @@ func resourceSentryDashboardUpdate(ctx context.Context, d *schema.ResourceData,
 	})
 	_, _, err = client.Dashboards.Update(ctx, org, dashboardID, dashboardReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryDashboard().Schema, dashboardFieldPaths)
 	}
 	return resourceSentryDashboardRead(ctx, d, meta)
 }
 
 func resourceSentryDashboardDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	if diags := checkDeletionProtection(d, meta); diags.HasError() {
+		return diags
+	}
+
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, dashboardID, err := splitSentryDashboardID(d.Id())
 	if err != nil {
@@ -341,7 +382,7 @@ func resourceSentryDashboardDelete(ctx context.Context, d *schema.ResourceData,
 		"dashboardID": dashboardID,
 	})
 	_, err = client.Dashboards.Delete(ctx, org, dashboardID)
-	return diag.FromErr(err)
+	return checkClientDelete(err)
 }
 
 func splitSentryDashboardID(id string) (org string, dashboardID string, err error) {
@@ -3,16 +3,25 @@ package sentry
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
+// metricAlertFieldPaths maps the fields of metric alert requests to the
+// attributes they are set from.
+var metricAlertFieldPaths = map[string]string{
+	"projects":           "project",
+	"triggers":           "trigger",
+	"triggers.*.actions": "trigger.*.action",
+}
+
 func resourceSentryMetricAlert() *schema.Resource {
 	return &schema.Resource{
 		Description: "Sentry Metric Alert resource.",
@@ -25,15 +34,20 @@ func resourceSentryMetricAlert() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
+
+		CustomizeDiff: customizeDiffDefaultOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the metric alert belongs to.",
+				Description: "The slug of the organization the metric alert belongs to. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"project": {
-				Description: "The slug of the project to create the metric alert for.",
+				Description: "The slug of the project to create the metric alert for. Changing it moves the metric alert to the project.",
 				Type:        schema.TypeString,
 				Required:    true,
 			},
@@ -212,8 +226,6 @@ func resourceSentryMetricAlertObject(d *schema.ResourceData) *sentry.MetricAlert
 }
 
 func resourceSentryMetricAlertCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
-
 	org := d.Get("organization").(string)
 	project := d.Get("project").(string)
 	alertReq := resourceSentryMetricAlertObject(d)
@@ -224,9 +236,37 @@ func resourceSentryMetricAlertCreate(ctx context.Context, d *schema.ResourceData
 		"ruleName": alertReq.Name,
 		"params":   fmt.Sprintf("%+v", alertReq),
 	})
-	alert, _, err := client.MetricAlerts.Create(ctx, org, project, alertReq)
+	alert, err := providerdata.CreateOnce(
+		ctx,
+		meta.(*providerdata.ProviderData),
+		"metric alert",
+		func(client *sentry.Client) (*sentry.MetricAlert, error) {
+			alert, _, err := client.MetricAlerts.Create(ctx, org, project, alertReq)
+			return alert, err
+		},
+		func(client *sentry.Client, since time.Time) (*sentry.MetricAlert, error) {
+			params := &sentry.ListCursorParams{}
+			for {
+				alerts, resp, err := client.MetricAlerts.List(ctx, org, project, params)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, alert := range alerts {
+					if sentry.StringValue(alert.Name) == sentry.StringValue(alertReq.Name) && alert.DateCreated != nil && !alert.DateCreated.Before(since) {
+						return alert, nil
+					}
+				}
+
+				if resp.Cursor == "" {
+					return nil, nil
+				}
+				params.Cursor = resp.Cursor
+			}
+		},
+	)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryMetricAlert().Schema, metricAlertFieldPaths)
 	}
 
 	d.SetId(buildThreePartID(org, project, sentry.StringValue(alert.ID)))
@@ -234,7 +274,7 @@ func resourceSentryMetricAlertCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceSentryMetricAlertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
@@ -247,15 +287,8 @@ func resourceSentryMetricAlertRead(ctx context.Context, d *schema.ResourceData,
 		"alertID": alertID,
 	})
 	alert, _, err := client.MetricAlerts.Get(ctx, org, project, alertID)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing metric alert from state because it no longer exists in Sentry", map[string]interface{}{"org": org})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(err, d); !found {
+		return diags
 	}
 	tflog.Debug(ctx, "Read metric alert", map[string]interface{}{
 		"alert": fmt.Sprintf("%+v", alert),
@@ -288,7 +321,7 @@ func resourceSentryMetricAlertRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceSentryMetricAlertUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
@@ -303,15 +336,16 @@ func resourceSentryMetricAlertUpdate(ctx context.Context, d *schema.ResourceData
 	})
 	alert, _, err := client.MetricAlerts.Update(ctx, org, project, alertID, alertReq)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryMetricAlert().Schema, metricAlertFieldPaths)
 	}
 
-	d.SetId(buildThreePartID(org, project, sentry.StringValue(alert.ID)))
+	// The alert is moved to the project in the request
+	d.SetId(buildThreePartID(org, d.Get("project").(string), sentry.StringValue(alert.ID)))
 	return resourceSentryMetricAlertRead(ctx, d, meta)
 }
 
 func resourceSentryMetricAlertDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, project, alertID, err := splitSentryAlertID(d.Id())
 	if err != nil {
@@ -324,7 +358,7 @@ func resourceSentryMetricAlertDelete(ctx context.Context, d *schema.ResourceData
 		"alertID": alertID,
 	})
 	_, err = client.MetricAlerts.Delete(ctx, org, project, alertID)
-	return diag.FromErr(err)
+	return checkClientDelete(err)
 }
 
 func expandMetricAlertTriggers(triggerList []interface{}) []*sentry.MetricAlertTrigger {
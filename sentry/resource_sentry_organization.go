@@ -2,13 +2,13 @@ package sentry
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 func resourceSentryOrganization() *schema.Resource {
@@ -22,6 +22,9 @@ func resourceSentryOrganization() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
+
+		CustomizeDiff: customizeDiffDeletionProtection(),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -45,12 +48,13 @@ func resourceSentryOrganization() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
 
 func resourceSentryOrganizationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	params := &sentry.CreateOrganizationParams{
 		Name:       sentry.String(d.Get("name").(string)),
@@ -63,7 +67,7 @@ func resourceSentryOrganizationCreate(ctx context.Context, d *schema.ResourceDat
 	tflog.Debug(ctx, "Creating organization", map[string]interface{}{"org": params.Name})
 	organization, _, err := client.Organizations.Create(ctx, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryOrganization().Schema, nil)
 	}
 
 	d.SetId(sentry.StringValue(organization.Slug))
@@ -71,20 +75,13 @@ func resourceSentryOrganizationCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceSentryOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 	org := d.Id()
 
 	tflog.Debug(ctx, "Reading organization", map[string]interface{}{"org": org})
 	organization, _, err := client.Organizations.Get(ctx, org)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing organization from state because it no longer exists in Sentry", map[string]interface{}{"org": org})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(err, d); !found {
+		return diags
 	}
 
 	retErr := multierror.Append(
@@ -92,12 +89,13 @@ func resourceSentryOrganizationRead(ctx context.Context, d *schema.ResourceData,
 		d.Set("slug", organization.Slug),
 		d.Set("agree_terms", true),
 		d.Set("internal_id", organization.ID),
+		setDefaultDeletionProtection(d, meta),
 	)
 	return diag.FromErr(retErr.ErrorOrNil())
 }
 
 func resourceSentryOrganizationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 	org := d.Id()
 	params := &sentry.UpdateOrganizationParams{
 		Name: sentry.String(d.Get("name").(string)),
@@ -109,7 +107,7 @@ func resourceSentryOrganizationUpdate(ctx context.Context, d *schema.ResourceDat
 	tflog.Debug(ctx, "Updating organization", map[string]interface{}{"org": org})
 	organization, _, err := client.Organizations.Update(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryOrganization().Schema, nil)
 	}
 
 	d.SetId(sentry.StringValue(organization.Slug))
@@ -117,10 +115,14 @@ func resourceSentryOrganizationUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceSentryOrganizationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	if diags := checkDeletionProtection(d, meta); diags.HasError() {
+		return diags
+	}
+
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 	org := d.Id()
 
 	tflog.Debug(ctx, "Deleting organization", map[string]interface{}{"org": org})
 	_, err := client.Organizations.Delete(ctx, org)
-	return diag.FromErr(err)
+	return checkClientDelete(err)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 func resourceSentryOrganizationCodeMapping() *schema.Resource {
@@ -21,12 +22,17 @@ func resourceSentryOrganizationCodeMapping() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importSentryOrganizationCodeMapping,
 		},
+		Timeouts: resourceTimeouts(),
+
+		CustomizeDiff: customizeDiffDefaultOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the code mapping is under.",
+				Description: "The slug of the organization the code mapping is under. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"integration_id": {
 				Description: "Sentry Organization Integration ID.",
@@ -70,7 +76,7 @@ func resourceSentryOrganizationCodeMapping() *schema.Resource {
 }
 
 func resourceSentryOrganizationCodeMappingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org := d.Get("organization").(string)
 
@@ -88,7 +94,7 @@ func resourceSentryOrganizationCodeMappingCreate(ctx context.Context, d *schema.
 	}
 	orgCodeMapping, _, err := client.OrganizationCodeMappings.Create(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryOrganizationCodeMapping().Schema, nil)
 	}
 
 	d.SetId(orgCodeMapping.ID)
@@ -97,7 +103,7 @@ func resourceSentryOrganizationCodeMappingCreate(ctx context.Context, d *schema.
 }
 
 func resourceSentryOrganizationCodeMappingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -150,11 +156,11 @@ func resourceSentryOrganizationCodeMappingRead(ctx context.Context, d *schema.Re
 		}
 	}
 
-	return diag.Errorf("Can't find Sentry Organization Code Mapping: %s", id)
+	return removeNotFound(d)
 }
 
 func resourceSentryOrganizationCodeMappingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -173,7 +179,7 @@ func resourceSentryOrganizationCodeMappingUpdate(ctx context.Context, d *schema.
 	})
 	orgCodeMapping, _, err := client.OrganizationCodeMappings.Update(ctx, org, id, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryOrganizationCodeMapping().Schema, nil)
 	}
 
 	d.SetId(orgCodeMapping.ID)
@@ -183,7 +189,7 @@ func resourceSentryOrganizationCodeMappingUpdate(ctx context.Context, d *schema.
 }
 
 func resourceSentryOrganizationCodeMappingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -193,7 +199,7 @@ func resourceSentryOrganizationCodeMappingDelete(ctx context.Context, d *schema.
 		"org": org,
 	})
 	_, err := client.OrganizationCodeMappings.Delete(ctx, org, id)
-	return diag.FromErr(err)
+	return checkClientDelete(err)
 }
 
 func importSentryOrganizationCodeMapping(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
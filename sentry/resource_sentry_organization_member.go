@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 func resourceSentryOrganizationMember() *schema.Resource {
@@ -23,17 +24,23 @@ func resourceSentryOrganizationMember() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
+
+		CustomizeDiff: customizeDiffDefaultOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the user should be invited to.",
+				Description: "The slug of the organization the user should be invited to. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"email": {
 				Description: "The email of the organization member.",
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 			},
 			"role": {
 				Description: "This is the role of the organization member.",
@@ -69,7 +76,7 @@ func resourceSentryOrganizationMember() *schema.Resource {
 }
 
 func resourceSentryOrganizationMemberCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org := d.Get("organization").(string)
 	params := &sentry.CreateOrganizationMemberParams{
@@ -84,7 +91,7 @@ func resourceSentryOrganizationMemberCreate(ctx context.Context, d *schema.Resou
 	})
 	member, _, err := client.OrganizationMembers.Create(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryOrganizationMember().Schema, nil)
 	}
 
 	d.SetId(buildTwoPartID(org, member.ID))
@@ -92,7 +99,7 @@ func resourceSentryOrganizationMemberCreate(ctx context.Context, d *schema.Resou
 }
 
 func resourceSentryOrganizationMemberRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, memberID, err := splitSentryOrganizationMemberID(d.Id())
 
@@ -100,13 +107,9 @@ func resourceSentryOrganizationMemberRead(ctx context.Context, d *schema.Resourc
 		"org":      org,
 		"memberID": memberID,
 	})
-	member, resp, err := client.OrganizationMembers.Get(ctx, org, memberID)
-	if found, err := checkClientGet(resp, err, d); !found {
-		tflog.Info(ctx, "Removed organization membership from state because it no longer exists in Sentry", map[string]interface{}{
-			"org":      org,
-			"memberID": memberID,
-		})
-		return diag.FromErr(err)
+	member, _, err := client.OrganizationMembers.Get(ctx, org, memberID)
+	if found, diags := checkClientGet(err, d); !found {
+		return diags
 	}
 
 	d.SetId(buildTwoPartID(org, member.ID))
@@ -122,7 +125,7 @@ func resourceSentryOrganizationMemberRead(ctx context.Context, d *schema.Resourc
 }
 
 func resourceSentryOrganizationMemberUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, memberID, err := splitSentryOrganizationMemberID(d.Id())
 	if err != nil {
@@ -148,7 +151,7 @@ func resourceSentryOrganizationMemberUpdate(ctx context.Context, d *schema.Resou
 
 	member, _, err := client.OrganizationMembers.Update(ctx, org, memberID, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryOrganizationMember().Schema, nil)
 	}
 
 	d.SetId(buildTwoPartID(org, member.ID))
@@ -156,7 +159,7 @@ func resourceSentryOrganizationMemberUpdate(ctx context.Context, d *schema.Resou
 }
 
 func resourceSentryOrganizationMemberDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org, memberID, err := splitSentryOrganizationMemberID(d.Id())
 	if err != nil {
@@ -168,7 +171,7 @@ func resourceSentryOrganizationMemberDelete(ctx context.Context, d *schema.Resou
 		"memberID": memberID,
 	})
 	_, err = client.OrganizationMembers.Delete(ctx, org, memberID)
-	return diag.FromErr(err)
+	return checkClientDelete(err)
 }
 
 func splitSentryOrganizationMemberID(id string) (org string, memberID string, err error) {
@@ -8,9 +8,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 // no UpdateContext, unsupported by this integration. will have to ForceNew
+// organizationRepositoryGithubFieldPaths maps the fields of repository
+// requests to the attributes they are set from.
+var organizationRepositoryGithubFieldPaths = map[string]string{
+	"installation": "integration_id",
+}
+
 func resourceSentryOrganizationRepositoryGithub() *schema.Resource {
 	return &schema.Resource{
 		Description: "Sentry Github Organization Repository resource.",
@@ -21,12 +28,20 @@ func resourceSentryOrganizationRepositoryGithub() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importSentryOrganizationRepositoryGithub,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(providerdata.DefaultCreateTimeout),
+			Read:   schema.DefaultTimeout(providerdata.DefaultReadTimeout),
+			Delete: schema.DefaultTimeout(providerdata.DefaultDeleteTimeout),
+		},
+
+		CustomizeDiff: customizeDiffDefaultOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the Sentry organization this resource belongs to.",
+				Description: "The slug of the Sentry organization this resource belongs to. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 			"integration_id": {
@@ -51,7 +66,7 @@ func resourceSentryOrganizationRepositoryGithub() *schema.Resource {
 }
 
 func resourceSentryOrganizationRepositoryGithubCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org := d.Get("organization").(string)
 	integrationId := d.Get("integration_id").(string)
@@ -71,7 +86,7 @@ func resourceSentryOrganizationRepositoryGithubCreate(ctx context.Context, d *sc
 	}
 	orgRepo, _, err := client.OrganizationRepositories.Create(ctx, org, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryOrganizationRepositoryGithub().Schema, organizationRepositoryGithubFieldPaths)
 	}
 
 	tflog.Debug(ctx, "Created Sentry Github Organization Repository", map[string]interface{}{
@@ -90,7 +105,7 @@ func resourceSentryOrganizationRepositoryGithubCreate(ctx context.Context, d *sc
 }
 
 func resourceSentryOrganizationRepositoryGithubRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -138,11 +153,11 @@ func resourceSentryOrganizationRepositoryGithubRead(ctx context.Context, d *sche
 		}
 	}
 
-	return diag.Errorf("Can't find Sentry Organization Repository: %s", id)
+	return removeNotFound(d)
 }
 
 func resourceSentryOrganizationRepositoryGithubDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -160,7 +175,7 @@ func resourceSentryOrganizationRepositoryGithubDelete(ctx context.Context, d *sc
 		"internalId": internalId,
 	})
 
-	return diag.FromErr(err)
+	return checkClientDelete(err)
 }
 
 func importSentryOrganizationRepositoryGithub(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -2,16 +2,21 @@ package sentry
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"net/http"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentrygrouping"
 	"github.com/jianyuan/terraform-provider-sentry/internal/sentryplatforms"
 )
 
@@ -27,12 +32,20 @@ func resourceSentryProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importOrganizationAndID,
 		},
+		Timeouts: resourceTimeouts(),
+
+		CustomizeDiff: customdiff.All(
+			customizeDiffDefaultOrganization,
+			customizeDiffDeletionProtection("organization"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the project belongs to.",
+				Description: "The slug of the organization the project belongs to. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"team": {
 				Description:   "The slug of the team to create the project for. **Deprecated** Use `teams` instead.",
@@ -133,13 +146,142 @@ func resourceSentryProject() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			// TODO: Project options
+			"subject_prefix": {
+				Description: "The prefix of the subject of the emails sent for the project.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"subject_template": {
+				Description: "The subject of the emails sent for individual alerts, excluding the prefix. Usable variables include `$title`, `$shortID`, `$projectID`, `$orgID` and `${tag:key}`, such as `${tag:environment}`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"allowed_domains": {
+				Description: "The domains allowed to send events to the project, based on the `Origin` or `Referer` header of the requests. `*` allows all domains.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"scrape_javascript": {
+				Description: "Whether Sentry fetches the JavaScript source files that were not uploaded.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"security_token": {
+				Description: "The token Sentry sends in the `security_token_header` header when fetching source files.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"security_token_header": {
+				Description: "The header Sentry sends the `security_token` in, such as `X-Sentry-Token`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"verify_ssl": {
+				Description: "Whether Sentry verifies the TLS certificates of the servers it fetches source files from.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"store_crash_reports": {
+				Description: "The number of native crash reports stored per issue. `0` disables storing them and `-1` stores all of them. Defaults to the organization setting.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"highlight_tags": {
+				Description: "The tags highlighted on the issues of the project.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"highlight_context": {
+				Description:      "The context keys highlighted on the issues of the project, as a JSON object of context types to lists of keys, such as `jsonencode({ trace = [\"trace_id\"] })`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+			},
+			"relay_pii_config": {
+				Description:      "The advanced data scrubbing rules of the project, as a JSON-encoded Relay PII config. Do not use it together with the `sentry_project_data_scrubbing_rule` resource, which manages the rules one by one.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: SuppressEquivalentJSONDiffs,
+			},
+			"data_scrubber": {
+				Description: "Whether Sentry scrubs the sensitive data of the events of the project on the server.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"data_scrubber_defaults": {
+				Description: "Whether Sentry scrubs the fields that commonly hold sensitive data, such as passwords and credit card numbers, in addition to the `sensitive_fields`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"scrub_ip_addresses": {
+				Description: "Whether Sentry stops storing the IP addresses of the events of the project.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"sensitive_fields": {
+				Description: "The names of additional fields that Sentry scrubs when `data_scrubber` is enabled.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"safe_fields": {
+				Description: "The names of the fields that Sentry does not scrub.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"fingerprinting_rules": {
+				Description:      "The [fingerprinting rules](https://docs.sentry.io/concepts/data-management/event-grouping/fingerprint-rules/) of the project, one per line, such as `error.type:DatabaseUnavailable -> system-down`. Changes to comments and whitespace are ignored.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateGroupingRules(sentrygrouping.ValidateFingerprintingRules),
+				DiffSuppressFunc: suppressEquivalentGroupingRules,
+			},
+			"grouping_enhancements": {
+				Description:      "The [stack trace rules](https://docs.sentry.io/concepts/data-management/event-grouping/stack-trace-rules/) of the project, one per line, such as `stack.function:panic_handler ^-group`. Changes to comments and whitespace are ignored.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validateGroupingRules(sentrygrouping.ValidateEnhancements),
+				DiffSuppressFunc: suppressEquivalentGroupingRules,
+			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
 
 func resourceSentryProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	org := d.Get("organization").(string)
 
@@ -178,7 +320,7 @@ func resourceSentryProjectCreate(ctx context.Context, d *schema.ResourceData, me
 	})
 	proj, _, err := client.Projects.Create(ctx, org, initialTeam, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryProject().Schema, nil)
 	}
 	tflog.Debug(ctx, "Created Sentry project", map[string]interface{}{
 		"projectSlug": proj.Slug,
@@ -200,7 +342,7 @@ func resourceSentryProjectCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceSentryProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	slug := d.Id()
 	org := d.Get("organization").(string)
@@ -209,9 +351,9 @@ func resourceSentryProjectRead(ctx context.Context, d *schema.ResourceData, meta
 		"projectSlug": slug,
 		"org":         org,
 	})
-	proj, resp, err := client.Projects.Get(ctx, org, slug)
-	if found, err := checkClientGet(resp, err, d); !found {
-		return diag.FromErr(err)
+	proj, _, err := sentryclient.GetProject(ctx, client, org, slug)
+	if found, diags := checkClientGet(err, d); !found {
+		return diags
 	}
 	tflog.Debug(ctx, "Read Sentry project", map[string]interface{}{
 		"projectSlug": proj.Slug,
@@ -234,6 +376,24 @@ func resourceSentryProjectRead(ctx context.Context, d *schema.ResourceData, meta
 		d.Set("digests_max_delay", proj.DigestsMaxDelay),
 		d.Set("resolve_age", proj.ResolveAge),
 		d.Set("project_id", proj.ID), // Deprecated
+		d.Set("subject_prefix", proj.SubjectPrefix),
+		d.Set("subject_template", proj.SubjectTemplate),
+		d.Set("allowed_domains", flattenStringSet(proj.AllowedDomains)),
+		d.Set("scrape_javascript", proj.ScrapeJavaScript),
+		d.Set("security_token", proj.SecurityToken),
+		d.Set("security_token_header", sentry.StringValue(proj.SecurityTokenHeader)),
+		d.Set("verify_ssl", proj.VerifySSL),
+		d.Set("store_crash_reports", proj.StoreCrashReports),
+		d.Set("highlight_tags", proj.HighlightTags),
+		d.Set("relay_pii_config", sentry.StringValue(proj.RelayPiiConfig)),
+		d.Set("data_scrubber", proj.DataScrubber),
+		d.Set("data_scrubber_defaults", proj.DataScrubberDefaults),
+		d.Set("scrub_ip_addresses", proj.ScrubIPAddresses),
+		d.Set("sensitive_fields", flattenStringSet(proj.SensitiveFields)),
+		d.Set("safe_fields", flattenStringSet(proj.SafeFields)),
+		d.Set("fingerprinting_rules", proj.FingerprintingRules),
+		d.Set("grouping_enhancements", proj.GroupingEnhancements),
+		setDefaultDeletionProtection(d, meta),
 	)
 	if _, ok := d.GetOk("team"); ok {
 		retErr = multierror.Append(retErr, d.Set("team", proj.Team.Slug))
@@ -245,19 +405,29 @@ func resourceSentryProjectRead(ctx context.Context, d *schema.ResourceData, meta
 		retErr = multierror.Append(retErr, d.Set("teams", flattenStringSet(teams)))
 	}
 
-	// TODO: Project options
+	highlightContext := ""
+	if proj.HighlightContext != nil {
+		b, err := json.Marshal(proj.HighlightContext)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		highlightContext = string(b)
+	}
+	retErr = multierror.Append(retErr, d.Set("highlight_context", highlightContext))
 
 	return diag.FromErr(retErr.ErrorOrNil())
 }
 
 func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	project := d.Id()
 	org := d.Get("organization").(string)
-	params := &sentry.UpdateProjectParams{
-		Name: d.Get("name").(string),
-		Slug: d.Get("slug").(string),
+	params := &sentryclient.UpdateProjectParams{
+		UpdateProjectParams: sentry.UpdateProjectParams{
+			Name: d.Get("name").(string),
+			Slug: d.Get("slug").(string),
+		},
 	}
 
 	platform := d.Get("platform").(string)
@@ -277,13 +447,86 @@ func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		params.ResolveAge = sentry.Int(v.(int))
 	}
 
+	// Only the options set in the configuration are updated, the others are
+	// left as they are set in Sentry
+	if isSetInConfig(d, "subject_prefix") {
+		params.SubjectPrefix = sentry.String(d.Get("subject_prefix").(string))
+	}
+	if isSetInConfig(d, "subject_template") {
+		params.SubjectTemplate = sentry.String(d.Get("subject_template").(string))
+	}
+	if isSetInConfig(d, "allowed_domains") {
+		allowedDomains := expandStringList(d.Get("allowed_domains").(*schema.Set).List())
+		params.AllowedDomains = &allowedDomains
+	}
+	if isSetInConfig(d, "scrape_javascript") {
+		params.ScrapeJavaScript = sentry.Bool(d.Get("scrape_javascript").(bool))
+	}
+	if isSetInConfig(d, "security_token") {
+		params.SecurityToken = sentry.String(d.Get("security_token").(string))
+	}
+	if isSetInConfig(d, "security_token_header") {
+		params.SecurityTokenHeader = sentry.String(d.Get("security_token_header").(string))
+	}
+	if isSetInConfig(d, "verify_ssl") {
+		params.VerifySSL = sentry.Bool(d.Get("verify_ssl").(bool))
+	}
+	if isSetInConfig(d, "store_crash_reports") {
+		params.StoreCrashReports = sentry.Int(d.Get("store_crash_reports").(int))
+	}
+	if isSetInConfig(d, "highlight_tags") {
+		highlightTags := expandStringList(d.Get("highlight_tags").([]interface{}))
+		params.HighlightTags = &highlightTags
+	}
+	if isSetInConfig(d, "highlight_context") {
+		var highlightContext map[string][]string
+		if err := json.Unmarshal([]byte(d.Get("highlight_context").(string)), &highlightContext); err != nil {
+			return diag.Errorf("highlight_context must be a JSON object of lists of strings: %s", err)
+		}
+		params.HighlightContext = &highlightContext
+	}
+	if isSetInConfig(d, "relay_pii_config") {
+		params.RelayPiiConfig = sentry.String(d.Get("relay_pii_config").(string))
+	}
+	if isSetInConfig(d, "data_scrubber") {
+		params.DataScrubber = sentry.Bool(d.Get("data_scrubber").(bool))
+	}
+	if isSetInConfig(d, "data_scrubber_defaults") {
+		params.DataScrubberDefaults = sentry.Bool(d.Get("data_scrubber_defaults").(bool))
+	}
+	if isSetInConfig(d, "scrub_ip_addresses") {
+		params.ScrubIPAddresses = sentry.Bool(d.Get("scrub_ip_addresses").(bool))
+	}
+	if isSetInConfig(d, "sensitive_fields") {
+		sensitiveFields := expandStringList(d.Get("sensitive_fields").(*schema.Set).List())
+		params.SensitiveFields = &sensitiveFields
+	}
+	if isSetInConfig(d, "safe_fields") {
+		safeFields := expandStringList(d.Get("safe_fields").(*schema.Set).List())
+		params.SafeFields = &safeFields
+	}
+	if isSetInConfig(d, "fingerprinting_rules") {
+		params.FingerprintingRules = sentry.String(d.Get("fingerprinting_rules").(string))
+	}
+	if isSetInConfig(d, "grouping_enhancements") {
+		params.GroupingEnhancements = sentry.String(d.Get("grouping_enhancements").(string))
+	}
+
 	tflog.Debug(ctx, "Updating project", map[string]interface{}{
 		"org":     org,
 		"project": project,
 	})
-	proj, _, err := client.Projects.Update(ctx, org, project, params)
+	if params.RelayPiiConfig != nil {
+		// The data scrubbing rule resources read, modify and write the rules too
+		unlock, err := providerdata.Lock(ctx, providerdata.RelayPiiConfigLockKey(org, project))
+		if err != nil {
+			return diag.Errorf("waiting for the other changes to the data scrubbing rules: %s", err)
+		}
+		defer unlock()
+	}
+	proj, _, err := sentryclient.UpdateProject(ctx, client, org, project, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryProject().Schema, nil)
 	}
 
 	d.SetId(proj.Slug)
@@ -342,11 +585,9 @@ func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 		})
 
 		for oldTeam := range oldTeams {
-			resp, err := client.Projects.RemoveTeam(ctx, org, project, oldTeam)
-			if err != nil {
-				if resp.Response.StatusCode != http.StatusNotFound {
-					return diag.FromErr(err)
-				}
+			_, err := client.Projects.RemoveTeam(ctx, org, project, oldTeam)
+			if err != nil && !sentryclient.IsNotFound(err) {
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -355,7 +596,11 @@ func resourceSentryProjectUpdate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceSentryProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	if diags := checkDeletionProtection(d, meta); diags.HasError() {
+		return diags
+	}
+
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	slug := d.Id()
 	org := d.Get("organization").(string)
@@ -365,12 +610,26 @@ func resourceSentryProjectDelete(ctx context.Context, d *schema.ResourceData, me
 		"org":         org,
 	})
 	_, err := client.Projects.Delete(ctx, org, slug)
-	tflog.Debug(ctx, "Deleted Sentry project", map[string]interface{}{
+	if err != nil {
+		return checkClientDelete(err)
+	}
+
+	// Sentry deletes projects asynchronously
+	tflog.Debug(ctx, "Waiting for Sentry project to be deleted", map[string]interface{}{
 		"projectSlug": slug,
 		"org":         org,
 	})
-
-	return diag.FromErr(err)
+	return waitForDeletion(ctx, d, func(ctx context.Context) (bool, error) {
+		proj, _, err := client.Projects.Get(ctx, org, slug)
+		if sentryclient.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		// A project pending deletion may be renamed to free its slug
+		return proj.Slug == slug, nil
+	})
 }
 
 func validatePlatform(i interface{}, path cty.Path) diag.Diagnostics {
@@ -391,6 +650,30 @@ func validatePlatform(i interface{}, path cty.Path) diag.Diagnostics {
 	return diagnostics
 }
 
+// validateGroupingRules validates the syntax of grouping rules with validate,
+// pointing to the line of the first error.
+func validateGroupingRules(validate func(rules string) error) schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		err := validate(i.(string))
+		if err == nil {
+			return nil
+		}
+
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid grouping rules",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+}
+
+// suppressEquivalentGroupingRules ignores changes to the comments and
+// whitespace of grouping rules.
+func suppressEquivalentGroupingRules(k, old, new string, d *schema.ResourceData) bool {
+	return sentrygrouping.Normalize(old) == sentrygrouping.Normalize(new)
+}
+
 func removeDefaultKey(ctx context.Context, client *sentry.Client, organizationSlug string, projectSlug string) error {
 	listParams := &sentry.ListProjectKeysParams{}
 
@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
 )
 
 func resourceSentryPlugin() *schema.Resource {
@@ -21,22 +21,29 @@ func resourceSentryPlugin() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importOrganizationProjectAndID,
 		},
+		Timeouts: resourceTimeouts(),
+
+		CustomizeDiff: customizeDiffDefaultOrganization,
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the project belongs to.",
+				Description: "The slug of the organization the project belongs to. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"project": {
 				Description: "The slug of the project to create the plugin for.",
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 			},
 			"plugin": {
 				Description: "Plugin ID.",
 				Type:        schema.TypeString,
 				Required:    true,
+				ForceNew:    true,
 			},
 			"config": {
 				Description: "Plugin config.",
@@ -48,7 +55,7 @@ func resourceSentryPlugin() *schema.Resource {
 }
 
 func resourceSentryPluginCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	plugin := d.Get("plugin").(string)
 	org := d.Get("organization").(string)
@@ -73,14 +80,14 @@ func resourceSentryPluginCreate(ctx context.Context, d *schema.ResourceData, met
 
 	params := d.Get("config").(map[string]interface{})
 	if _, _, err := client.ProjectPlugins.Update(ctx, org, project, plugin, params); err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryPlugin().Schema, nil)
 	}
 
 	return resourceSentryPluginRead(ctx, d, meta)
 }
 
 func resourceSentryPluginRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -91,9 +98,9 @@ func resourceSentryPluginRead(ctx context.Context, d *schema.ResourceData, meta
 		"org":      org,
 		"project":  project,
 	})
-	plugin, resp, err := client.ProjectPlugins.Get(ctx, org, project, id)
-	if found, err := checkClientGet(resp, err, d); !found {
-		return diag.FromErr(err)
+	plugin, _, err := client.ProjectPlugins.Get(ctx, org, project, id)
+	if found, diags := checkClientGet(err, d); !found {
+		return diags
 	}
 	tflog.Debug(ctx, "Read Sentry plugin", map[string]interface{}{
 		"pluginID": plugin.ID,
@@ -122,7 +129,7 @@ func resourceSentryPluginRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceSentryPluginUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -136,7 +143,7 @@ func resourceSentryPluginUpdate(ctx context.Context, d *schema.ResourceData, met
 	params := d.Get("config").(map[string]interface{})
 	plugin, _, err := client.ProjectPlugins.Update(ctx, org, project, id, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryPlugin().Schema, nil)
 	}
 	tflog.Debug(ctx, "Updated Sentry plugin", map[string]interface{}{
 		"pluginID": plugin.ID,
@@ -148,7 +155,7 @@ func resourceSentryPluginUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceSentryPluginDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	id := d.Id()
 	org := d.Get("organization").(string)
@@ -166,5 +173,5 @@ func resourceSentryPluginDelete(ctx context.Context, d *schema.ResourceData, met
 		"project":  project,
 	})
 
-	return diag.FromErr(err)
+	return checkClientDelete(err)
 }
@@ -265,6 +265,190 @@ func TestAccSentryProject_noDefaultKey(t *testing.T) {
 	})
 }
 
+func TestAccSentryProject_options(t *testing.T) {
+	teamName := acctest.RandomWithPrefix("tf-team")
+	projectName := acctest.RandomWithPrefix("tf-project")
+	rn := "sentry_project.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSentryProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSentryProjectConfig_options(teamName, projectName, `
+	subject_prefix        = "[tf] "
+	subject_template      = "$shortID - $title"
+	allowed_domains       = ["example.com", "*.example.com"]
+	scrape_javascript     = false
+	security_token        = "0123456789abcdef"
+	security_token_header = "X-Sentry-Token"
+	verify_ssl            = true
+	store_crash_reports   = 5
+	highlight_tags        = ["release", "environment"]
+	highlight_context     = jsonencode({ trace = ["trace_id"] })
+	relay_pii_config      = jsonencode({ rules = {}, applications = { "$string" = ["@ip"] } })
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rn, "subject_prefix", "[tf] "),
+					resource.TestCheckResourceAttr(rn, "subject_template", "$shortID - $title"),
+					resource.TestCheckResourceAttr(rn, "allowed_domains.#", "2"),
+					resource.TestCheckTypeSetElemAttr(rn, "allowed_domains.*", "*.example.com"),
+					resource.TestCheckResourceAttr(rn, "scrape_javascript", "false"),
+					resource.TestCheckResourceAttr(rn, "security_token", "0123456789abcdef"),
+					resource.TestCheckResourceAttr(rn, "security_token_header", "X-Sentry-Token"),
+					resource.TestCheckResourceAttr(rn, "verify_ssl", "true"),
+					resource.TestCheckResourceAttr(rn, "store_crash_reports", "5"),
+					resource.TestCheckResourceAttr(rn, "highlight_tags.#", "2"),
+					resource.TestCheckResourceAttr(rn, "highlight_tags.0", "release"),
+					resource.TestCheckResourceAttr(rn, "highlight_context", `{"trace":["trace_id"]}`),
+					resource.TestCheckResourceAttrSet(rn, "relay_pii_config"),
+				),
+			},
+			{
+				Config: testAccSentryProjectConfig_options(teamName, projectName, `
+	subject_prefix    = ""
+	allowed_domains   = ["*"]
+	scrape_javascript = true
+	verify_ssl        = false
+	highlight_tags    = []
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rn, "subject_prefix", ""),
+					resource.TestCheckResourceAttr(rn, "subject_template", "$shortID - $title"),
+					resource.TestCheckResourceAttr(rn, "allowed_domains.#", "1"),
+					resource.TestCheckResourceAttr(rn, "scrape_javascript", "true"),
+					resource.TestCheckResourceAttr(rn, "verify_ssl", "false"),
+					resource.TestCheckResourceAttr(rn, "store_crash_reports", "5"),
+					resource.TestCheckResourceAttr(rn, "highlight_tags.#", "0"),
+				),
+			},
+			{
+				ResourceName:            rn,
+				ImportState:             true,
+				ImportStateIdFunc:       testAccSentryProjectImportStateIdFunc(rn),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"default_key", "default_rules"},
+			},
+		},
+	})
+}
+
+func TestAccSentryProject_dataScrubbing(t *testing.T) {
+	teamName := acctest.RandomWithPrefix("tf-team")
+	projectName := acctest.RandomWithPrefix("tf-project")
+	rn := "sentry_project.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSentryProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSentryProjectConfig_options(teamName, projectName, `
+	data_scrubber          = true
+	data_scrubber_defaults = true
+	scrub_ip_addresses     = true
+	sensitive_fields       = ["session_id", "api_key"]
+	safe_fields            = ["order_id"]
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rn, "data_scrubber", "true"),
+					resource.TestCheckResourceAttr(rn, "data_scrubber_defaults", "true"),
+					resource.TestCheckResourceAttr(rn, "scrub_ip_addresses", "true"),
+					resource.TestCheckResourceAttr(rn, "sensitive_fields.#", "2"),
+					resource.TestCheckTypeSetElemAttr(rn, "sensitive_fields.*", "session_id"),
+					resource.TestCheckTypeSetElemAttr(rn, "sensitive_fields.*", "api_key"),
+					resource.TestCheckResourceAttr(rn, "safe_fields.#", "1"),
+					resource.TestCheckTypeSetElemAttr(rn, "safe_fields.*", "order_id"),
+				),
+			},
+			{
+				Config: testAccSentryProjectConfig_options(teamName, projectName, `
+	data_scrubber          = false
+	data_scrubber_defaults = false
+	scrub_ip_addresses     = false
+	sensitive_fields       = []
+	safe_fields            = []
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rn, "data_scrubber", "false"),
+					resource.TestCheckResourceAttr(rn, "data_scrubber_defaults", "false"),
+					resource.TestCheckResourceAttr(rn, "scrub_ip_addresses", "false"),
+					resource.TestCheckResourceAttr(rn, "sensitive_fields.#", "0"),
+					resource.TestCheckResourceAttr(rn, "safe_fields.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSentryProject_grouping(t *testing.T) {
+	teamName := acctest.RandomWithPrefix("tf-team")
+	projectName := acctest.RandomWithPrefix("tf-project")
+	rn := "sentry_project.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSentryProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSentryProjectConfig_options(teamName, projectName, `
+	fingerprinting_rules = <<-EOT
+		# Group database errors together
+		error.type:DatabaseUnavailable -> system-down
+		stack.function:"connect" -> {{ default }}, connection title="Connection error"
+	EOT
+	grouping_enhancements = <<-EOT
+		stack.function:panic_handler ^-group
+		stack.module:myapp.* +app
+	EOT
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rn, "fingerprinting_rules", "# Group database errors together\nerror.type:DatabaseUnavailable -> system-down\nstack.function:\"connect\" -> {{ default }}, connection title=\"Connection error\"\n"),
+					resource.TestCheckResourceAttr(rn, "grouping_enhancements", "stack.function:panic_handler ^-group\nstack.module:myapp.* +app\n"),
+				),
+			},
+			{
+				// Changes to comments and whitespace are ignored
+				Config: testAccSentryProjectConfig_options(teamName, projectName, `
+	fingerprinting_rules = <<-EOT
+		error.type:DatabaseUnavailable   ->   system-down
+
+		stack.function:"connect" -> {{ default }}, connection title="Connection error"
+	EOT
+	grouping_enhancements = <<-EOT
+		# Stack trace rules
+		stack.function:panic_handler  ^-group
+		stack.module:myapp.*  +app
+	EOT
+				`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccSentryProjectConfig_options(teamName, projectName, `
+	fingerprinting_rules = <<-EOT
+		error.type:DatabaseUnavailable -> system-down
+		error.type:ConnectionError connection-error
+	EOT
+				`),
+				ExpectError: regexp.MustCompile("line 2: expected `->` after the matchers"),
+			},
+			{
+				Config: testAccSentryProjectConfig_options(teamName, projectName, `
+	fingerprinting_rules  = ""
+	grouping_enhancements = ""
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(rn, "fingerprinting_rules", ""),
+					resource.TestCheckResourceAttr(rn, "grouping_enhancements", ""),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSentryProjectDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_project" {
@@ -470,3 +654,15 @@ resource "sentry_project" "test" {
 }
 `, acctest.TestOrganization, teamName, projectName)
 }
+
+func testAccSentryProjectConfig_options(teamName, projectName, options string) string {
+	return testAccSentryTeamConfig(teamName) + fmt.Sprintf(`
+resource "sentry_project" "test" {
+	organization = sentry_team.test.organization
+	teams        = [sentry_team.test.slug]
+	name         = "%[1]s"
+	platform     = "go"
+%[2]s
+}
+	`, projectName, options)
+}
@@ -2,13 +2,16 @@ package sentry
 
 import (
 	"context"
-	"net/http"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jianyuan/go-sentry/v2/sentry"
+	"github.com/jianyuan/terraform-provider-sentry/internal/providerdata"
+	"github.com/jianyuan/terraform-provider-sentry/internal/sentryclient"
 )
 
 func resourceSentryTeam() *schema.Resource {
@@ -22,12 +25,20 @@ func resourceSentryTeam() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: importOrganizationAndID,
 		},
+		Timeouts: resourceTimeouts(),
+
+		CustomizeDiff: customdiff.All(
+			customizeDiffDefaultOrganization,
+			customizeDiffDeletionProtection("organization"),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"organization": {
-				Description: "The slug of the organization the team should be created for.",
+				Description: "The slug of the organization the team should be created for. Defaults to the provider-level `organization`.",
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
 			},
 			"name": {
 				Description: "The name of the team.",
@@ -63,13 +74,12 @@ func resourceSentryTeam() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"deletion_protection": deletionProtectionSchema(),
 		},
 	}
 }
 
 func resourceSentryTeamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
-
 	org := d.Get("organization").(string)
 	params := &sentry.CreateTeamParams{
 		Name: sentry.String(d.Get("name").(string)),
@@ -79,32 +89,73 @@ func resourceSentryTeamCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	tflog.Debug(ctx, "Creating team", map[string]interface{}{"org": org, "teamName": params.Name})
-	team, _, err := client.Teams.Create(ctx, org, params)
+	team, err := providerdata.CreateOnce(
+		ctx,
+		meta.(*providerdata.ProviderData),
+		"team",
+		func(client *sentry.Client) (*sentry.Team, error) {
+			team, _, err := client.Teams.Create(ctx, org, params)
+			return team, err
+		},
+		func(client *sentry.Client, since time.Time) (*sentry.Team, error) {
+			return findSentryTeam(ctx, client, org, params, since)
+		},
+	)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryTeam().Schema, nil)
 	}
 
 	d.SetId(sentry.StringValue(team.Slug))
 	return resourceSentryTeamRead(ctx, d, meta)
 }
 
+// findSentryTeam returns the team created since the given time with the slug,
+// or the name if there is no slug, of params.
+func findSentryTeam(ctx context.Context, client *sentry.Client, org string, params *sentry.CreateTeamParams, since time.Time) (*sentry.Team, error) {
+	if params.Slug != nil {
+		team, _, err := client.Teams.Get(ctx, org, *params.Slug)
+		if sentryclient.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if team.DateCreated == nil || team.DateCreated.Before(since) {
+			return nil, nil
+		}
+		return team, nil
+	}
+
+	listParams := &sentry.ListCursorParams{}
+	for {
+		teams, resp, err := client.Teams.List(ctx, org, listParams)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, team := range teams {
+			if sentry.StringValue(team.Name) == sentry.StringValue(params.Name) && team.DateCreated != nil && !team.DateCreated.Before(since) {
+				return team, nil
+			}
+		}
+
+		if resp.Cursor == "" {
+			return nil, nil
+		}
+		listParams.Cursor = resp.Cursor
+	}
+}
+
 func resourceSentryTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	teamSlug := d.Id()
 	org := d.Get("organization").(string)
 
 	tflog.Debug(ctx, "Reading team", map[string]interface{}{"org": org, "team": teamSlug})
 	team, _, err := client.Teams.Get(ctx, org, teamSlug)
-	if err != nil {
-		if sErr, ok := err.(*sentry.ErrorResponse); ok {
-			if sErr.Response.StatusCode == http.StatusNotFound {
-				tflog.Info(ctx, "Removing team from state because it no longer exists in Sentry", map[string]interface{}{"team": teamSlug})
-				d.SetId("")
-				return nil
-			}
-		}
-		return diag.FromErr(err)
+	if found, diags := checkClientGet(err, d); !found {
+		return diags
 	}
 
 	retErr := multierror.Append(
@@ -116,12 +167,13 @@ func resourceSentryTeamRead(ctx context.Context, d *schema.ResourceData, meta in
 		d.Set("is_pending", team.IsPending),
 		d.Set("is_member", team.IsMember),
 		d.Set("team_id", team.ID), // Deprecated
+		setDefaultDeletionProtection(d, meta),
 	)
 	return diag.FromErr(retErr.ErrorOrNil())
 }
 
 func resourceSentryTeamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	teamSlug := d.Id()
 	org := d.Get("organization").(string)
@@ -133,7 +185,7 @@ func resourceSentryTeamUpdate(ctx context.Context, d *schema.ResourceData, meta
 	tflog.Debug(ctx, "Updating team", map[string]interface{}{"org": org, "team": teamSlug})
 	team, _, err := client.Teams.Update(ctx, org, teamSlug, params)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagFromClientErr(err, resourceSentryTeam().Schema, nil)
 	}
 
 	d.SetId(sentry.StringValue(team.Slug))
@@ -141,12 +193,28 @@ func resourceSentryTeamUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceSentryTeamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*sentry.Client)
+	if diags := checkDeletionProtection(d, meta); diags.HasError() {
+		return diags
+	}
+
+	client := meta.(*providerdata.ProviderData).ClientFor(ctx)
 
 	teamSlug := d.Id()
 	org := d.Get("organization").(string)
 
 	tflog.Debug(ctx, "Deleting team", map[string]interface{}{"org": org, "team": teamSlug})
 	_, err := client.Teams.Delete(ctx, org, teamSlug)
-	return diag.FromErr(err)
+	if err != nil {
+		return checkClientDelete(err)
+	}
+
+	// Sentry deletes teams asynchronously
+	tflog.Debug(ctx, "Waiting for team to be deleted", map[string]interface{}{"org": org, "team": teamSlug})
+	return waitForDeletion(ctx, d, func(ctx context.Context) (bool, error) {
+		_, _, err := client.Teams.Get(ctx, org, teamSlug)
+		if sentryclient.IsNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -56,6 +57,44 @@ func TestAccSentryTeam_basic(t *testing.T) {
 	})
 }
 
+func TestAccSentryTeam_deletionProtection(t *testing.T) {
+	teamName := acctest.RandomWithPrefix("tf-team")
+	rn := "sentry_team.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSentryTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSentryTeamDeletionProtectionConfig(teamName, true),
+				Check:  resource.TestCheckResourceAttr(rn, "deletion_protection", "true"),
+			},
+			{
+				Config:      testAccSentryTeamDeletionProtectionConfig(teamName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Deletion protection is enabled"),
+			},
+			{
+				// Moving the team to another organization replaces it
+				Config: fmt.Sprintf(`
+resource "sentry_team" "test" {
+	organization        = "%[1]s-moved"
+	name                = "%[1]s"
+	slug                = "%[1]s"
+	deletion_protection = true
+}
+				`, teamName),
+				ExpectError: regexp.MustCompile("deletion protection is enabled"),
+			},
+			{
+				Config: testAccSentryTeamDeletionProtectionConfig(teamName, false),
+				Check:  resource.TestCheckResourceAttr(rn, "deletion_protection", "false"),
+			},
+		},
+	})
+}
+
 func testAccCheckSentryTeamDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "sentry_team" {
@@ -125,3 +164,14 @@ resource "sentry_team" "test" {
 }
 	`, teamName)
 }
+
+func testAccSentryTeamDeletionProtectionConfig(teamName string, deletionProtection bool) string {
+	return testAccSentryOrganizationDataSourceConfig + fmt.Sprintf(`
+resource "sentry_team" "test" {
+	organization        = data.sentry_organization.test.id
+	name                = "%[1]s"
+	slug                = "%[1]s"
+	deletion_protection = %[2]t
+}
+	`, teamName, deletionProtection)
+}